@@ -6,9 +6,13 @@ package address
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Address represents an address.
@@ -35,6 +39,27 @@ func (a Address) IsEmpty() bool {
 	return a.CountryCode == ""
 }
 
+// getField returns the value of the given field.
+func (a Address) getField(field Field) string {
+	switch field {
+	case FieldLine1:
+		return a.Line1
+	case FieldLine2:
+		return a.Line2
+	case FieldLine3:
+		return a.Line3
+	case FieldSublocality:
+		return a.Sublocality
+	case FieldLocality:
+		return a.Locality
+	case FieldRegion:
+		return a.Region
+	case FieldPostalCode:
+		return a.PostalCode
+	}
+	return ""
+}
+
 // Format represents an address format.
 type Format struct {
 	Locale            Locale           `json:"locale,omitempty"`
@@ -50,6 +75,13 @@ type Format struct {
 	ShowRegionID      bool             `json:"show_region_id,omitempty"`
 	Regions           RegionMap        `json:"regions,omitempty"`
 	LocalRegions      RegionMap        `json:"local_regions,omitempty"`
+	// MaxLengths are the maximum number of characters, keyed by field.
+	// Fields missing from the map are unlimited.
+	MaxLengths map[Field]int `json:"max_lengths,omitempty"`
+	// Scripts are the allowed ISO 15924 script codes (e.g. "Latn", "Cyrl").
+	// "Jpan" covers Han and kana, "Kore" covers Hangul and Han.
+	// An empty list allows any script.
+	Scripts []string `json:"scripts,omitempty"`
 }
 
 // IsRequired returns whether the given field is required.
@@ -91,6 +123,38 @@ func (f Format) CheckPostalCode(postalCode string) bool {
 	return rx.MatchString(postalCode)
 }
 
+// MaxLength returns the maximum number of characters for the given field.
+//
+// Returns 0 if the field is unlimited.
+func (f Format) MaxLength(field Field) int {
+	return f.MaxLengths[field]
+}
+
+// CheckLength checks whether the given value is within the field's maximum length.
+func (f Format) CheckLength(field Field, value string) bool {
+	maxLength := f.MaxLength(field)
+	return maxLength == 0 || utf8.RuneCountInString(value) <= maxLength
+}
+
+// CheckScript checks whether the given value only uses allowed scripts.
+//
+// Only letters are checked, digits and punctuation are always allowed,
+// as are letters shared between scripts (e.g. the "ー" prolonged sound mark).
+func (f Format) CheckScript(value string) bool {
+	if len(f.Scripts) == 0 {
+		return true
+	}
+	for _, r := range value {
+		if !unicode.IsLetter(r) || unicode.Is(unicode.Common, r) {
+			continue
+		}
+		if !scriptAllowed(scriptOf(r), f.Scripts) {
+			return false
+		}
+	}
+	return true
+}
+
 // PostalCodeValidationPattern returns the full regex pattern for validating the postal code.
 func (f *Format) PostalCodeValidationPattern() string {
 	return "^" + f.PostalCodePattern + "$"
@@ -104,6 +168,11 @@ func (f Format) SelectLayout(locale Locale) string {
 	return f.Layout
 }
 
+// SelectParsedLayout selects and parses the correct layout for the given locale.
+func (f Format) SelectParsedLayout(locale Locale) Layout {
+	return ParseLayout(f.SelectLayout(locale))
+}
+
 // SelectRegions selects the correct regions for the given locale.
 func (f Format) SelectRegions(locale Locale) RegionMap {
 	if f.LocalRegions.Len() > 0 && f.useLocalData(locale) {
@@ -181,18 +250,82 @@ func (r RegionMap) MarshalJSON() ([]byte, error) {
 		if i > 0 {
 			buf.WriteByte(',')
 		}
-		// A fully generic MarshalJSON would call encoder.Encode() to ensure both key and value
-		// are escaped and contain valid utf8. Since all regions are defined in the package, they
-		// are assumed to be valid, avoiding thousands of allocs when marshalling the format list.
-		buf.WriteString(`"` + key + `":"`)
-		buf.WriteString(r.values[key])
-		buf.WriteString(`"`)
+		writeJSONString(buf, key)
+		buf.WriteByte(':')
+		writeJSONString(buf, r.values[key])
 	}
 	buf.WriteByte('}')
 
 	return buf.Bytes(), nil
 }
 
+// writeJSONString writes the given string as a JSON string.
+//
+// Strings that need no escaping (such as all built-in region names) are
+// written directly, avoiding thousands of allocs when marshalling the format
+// list. Other strings (e.g. regions loaded into a Registry) are escaped by
+// encoding/json.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	if needsJSONEscape(s) {
+		b, _ := json.Marshal(s)
+		buf.Write(b)
+		return
+	}
+	buf.WriteByte('"')
+	buf.WriteString(s)
+	buf.WriteByte('"')
+}
+
+// needsJSONEscape returns whether the given string needs escaping in JSON.
+//
+// HTML characters are not checked, encoding/json escapes them in the output
+// of MarshalJSON.
+func needsJSONEscape(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c == '"' || c == '\\' {
+			return true
+		}
+	}
+	return !utf8.ValidString(s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// The order of the keys is preserved.
+func (r *RegionMap) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		*r = RegionMap{}
+		return nil
+	}
+	if delim, ok := t.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("invalid region map: expected an object")
+	}
+	var pairs []string
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := t.(string)
+		var value string
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("invalid region map: key %q: %w", key, err)
+		}
+		pairs = append(pairs, key, value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	*r = NewRegionMap(pairs...)
+
+	return nil
+}
+
 // CheckCountryCode checks whether the given country code is valid.
 //
 // An empty country code is considered valid.
@@ -219,6 +352,46 @@ func GetCountryNames() map[string]string {
 	return countries
 }
 
+// lookupCountryCode returns the country code for the given country code or English name.
+//
+// Names are matched case-insensitively, with "&" and "and" treated as equivalent.
+func lookupCountryCode(country string) (string, bool) {
+	country = strings.TrimSpace(country)
+	if len(country) == 2 {
+		countryCode := strings.ToUpper(country)
+		if _, ok := countries[countryCode]; ok {
+			return countryCode, true
+		}
+	}
+	normalize := strings.NewReplacer(" and ", " & ")
+	country = normalize.Replace(strings.ToLower(country))
+	for countryCode, name := range countries {
+		if strings.ToLower(name) == country {
+			return countryCode, true
+		}
+	}
+	return "", false
+}
+
+// lookupRegion returns the region ID for the given region ID or name.
+//
+// Names are matched case-insensitively against both the regions and the local regions.
+// The region is returned unchanged if the address format has no predefined regions,
+// or if no match was found.
+func lookupRegion(format Format, region string) string {
+	if region == "" || format.Regions.HasKey(region) {
+		return region
+	}
+	for _, regions := range []RegionMap{format.Regions, format.LocalRegions} {
+		for _, key := range regions.Keys() {
+			if name, _ := regions.Get(key); strings.EqualFold(name, region) || strings.EqualFold(key, region) {
+				return key
+			}
+		}
+	}
+	return region
+}
+
 // GetFormats returns all known address formats, keyed by country code.
 //
 // The ZZ address format represents the generic fallback.
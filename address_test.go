@@ -128,6 +128,60 @@ func TestFormat_CheckPostalCode(t *testing.T) {
 	}
 }
 
+func TestFormat_CheckLength(t *testing.T) {
+	format := address.Format{
+		MaxLengths: map[address.Field]int{address.FieldLine1: 10},
+	}
+	tests := []struct {
+		field address.Field
+		value string
+		want  bool
+	}{
+		{address.FieldLine1, "", true},
+		{address.FieldLine1, "Alta Ave 1", true},
+		{address.FieldLine1, "Alta Ave 10", false},
+		// Length is measured in characters, not bytes.
+		{address.FieldLine1, "Šumadijska", true},
+		// Field without a limit.
+		{address.FieldLine2, "Building 5, Suite 100", true},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := format.CheckLength(tt.field, tt.value)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat_CheckScript(t *testing.T) {
+	tests := []struct {
+		scripts []string
+		value   string
+		want    bool
+	}{
+		{nil, "ул. Тверская", true},
+		{[]string{"Latn"}, "", true},
+		{[]string{"Latn"}, "1098 Alta Ave #5", true},
+		{[]string{"Latn"}, "Kadıköy", true},
+		{[]string{"Latn"}, "ул. Тверская", false},
+		{[]string{"Cyrl", "Latn"}, "ул. Тверская, ап. B", true},
+		{[]string{"Jpan"}, "丸の内1-1", true},
+		{[]string{"Jpan"}, "丸の内センタービル", true},
+		{[]string{"Kore"}, "丸の内1-1", false},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			format := address.Format{Scripts: tt.scripts}
+			got := format.CheckScript(tt.value)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFormat_SelectLayout(t *testing.T) {
 	tests := []struct {
 		countryCode string
@@ -255,6 +309,34 @@ func TestRegionMap(t *testing.T) {
 	if !reflect.DeepEqual(gotBytes, wantBytes) {
 		t.Errorf(`got %v want %v`, string(gotBytes), string(wantBytes))
 	}
+
+	var decoded address.RegionMap
+	err = json.Unmarshal(wantBytes, &decoded)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(decoded, r) {
+		t.Errorf(`got %v want %v`, decoded, r)
+	}
+	err = json.Unmarshal([]byte(`["15", "Artemisa"]`), &decoded)
+	if err == nil {
+		t.Error("expected an error")
+	}
+
+	// Keys and values are escaped.
+	r = address.NewRegionMap(`A"B`, `Quote "here"`, "C\\D", "Line\nbreak")
+	gotBytes, err = json.Marshal(r)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	wantBytes = []byte(`{"A\"B":"Quote \"here\"","C\\D":"Line\nbreak"}`)
+	if !reflect.DeepEqual(gotBytes, wantBytes) {
+		t.Errorf(`got %v want %v`, string(gotBytes), string(wantBytes))
+	}
+	decoded = address.RegionMap{}
+	if err := json.Unmarshal(gotBytes, &decoded); err != nil || !reflect.DeepEqual(decoded, r) {
+		t.Errorf("got %v, %v, want %v", decoded, err, r)
+	}
 }
 
 func TestCheckCountryCode(t *testing.T) {
@@ -356,7 +438,7 @@ func TestGetFormats_ValidPostalCodePatterns(t *testing.T) {
 
 func TestGetFormats_ValidRegionData(t *testing.T) {
 	// Confirm that all regions contain valid utf8.
-	// Avoids having the check at runtime, in RegionMap.MarshalJSON.
+	// Keeps them on the fast path in RegionMap.MarshalJSON.
 	formats := address.GetFormats()
 	for countryCode, format := range formats {
 		if format.Regions.Len() > 0 {
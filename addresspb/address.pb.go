@@ -0,0 +1,997 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: address.proto
+
+package addresspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Field represents an address field.
+type Field int32
+
+const (
+	Field_FIELD_UNSPECIFIED Field = 0
+	Field_FIELD_LINE1       Field = 1
+	Field_FIELD_LINE2       Field = 2
+	Field_FIELD_LINE3       Field = 3
+	Field_FIELD_SUBLOCALITY Field = 4
+	Field_FIELD_LOCALITY    Field = 5
+	Field_FIELD_REGION      Field = 6
+	Field_FIELD_POSTAL_CODE Field = 7
+)
+
+// Enum value maps for Field.
+var (
+	Field_name = map[int32]string{
+		0: "FIELD_UNSPECIFIED",
+		1: "FIELD_LINE1",
+		2: "FIELD_LINE2",
+		3: "FIELD_LINE3",
+		4: "FIELD_SUBLOCALITY",
+		5: "FIELD_LOCALITY",
+		6: "FIELD_REGION",
+		7: "FIELD_POSTAL_CODE",
+	}
+	Field_value = map[string]int32{
+		"FIELD_UNSPECIFIED": 0,
+		"FIELD_LINE1":       1,
+		"FIELD_LINE2":       2,
+		"FIELD_LINE3":       3,
+		"FIELD_SUBLOCALITY": 4,
+		"FIELD_LOCALITY":    5,
+		"FIELD_REGION":      6,
+		"FIELD_POSTAL_CODE": 7,
+	}
+)
+
+func (x Field) Enum() *Field {
+	p := new(Field)
+	*p = x
+	return p
+}
+
+func (x Field) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Field) Descriptor() protoreflect.EnumDescriptor {
+	return file_address_proto_enumTypes[0].Descriptor()
+}
+
+func (Field) Type() protoreflect.EnumType {
+	return &file_address_proto_enumTypes[0]
+}
+
+func (x Field) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Field.Descriptor instead.
+func (Field) EnumDescriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{0}
+}
+
+// SublocalityType represents the sublocality type.
+type SublocalityType int32
+
+const (
+	SublocalityType_SUBLOCALITY_TYPE_UNSPECIFIED      SublocalityType = 0
+	SublocalityType_SUBLOCALITY_TYPE_SUBURB           SublocalityType = 1
+	SublocalityType_SUBLOCALITY_TYPE_DISTRICT         SublocalityType = 2
+	SublocalityType_SUBLOCALITY_TYPE_NEIGHBORHOOD     SublocalityType = 3
+	SublocalityType_SUBLOCALITY_TYPE_VILLAGE_TOWNSHIP SublocalityType = 4
+	SublocalityType_SUBLOCALITY_TYPE_TOWNLAND         SublocalityType = 5
+)
+
+// Enum value maps for SublocalityType.
+var (
+	SublocalityType_name = map[int32]string{
+		0: "SUBLOCALITY_TYPE_UNSPECIFIED",
+		1: "SUBLOCALITY_TYPE_SUBURB",
+		2: "SUBLOCALITY_TYPE_DISTRICT",
+		3: "SUBLOCALITY_TYPE_NEIGHBORHOOD",
+		4: "SUBLOCALITY_TYPE_VILLAGE_TOWNSHIP",
+		5: "SUBLOCALITY_TYPE_TOWNLAND",
+	}
+	SublocalityType_value = map[string]int32{
+		"SUBLOCALITY_TYPE_UNSPECIFIED":      0,
+		"SUBLOCALITY_TYPE_SUBURB":           1,
+		"SUBLOCALITY_TYPE_DISTRICT":         2,
+		"SUBLOCALITY_TYPE_NEIGHBORHOOD":     3,
+		"SUBLOCALITY_TYPE_VILLAGE_TOWNSHIP": 4,
+		"SUBLOCALITY_TYPE_TOWNLAND":         5,
+	}
+)
+
+func (x SublocalityType) Enum() *SublocalityType {
+	p := new(SublocalityType)
+	*p = x
+	return p
+}
+
+func (x SublocalityType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SublocalityType) Descriptor() protoreflect.EnumDescriptor {
+	return file_address_proto_enumTypes[1].Descriptor()
+}
+
+func (SublocalityType) Type() protoreflect.EnumType {
+	return &file_address_proto_enumTypes[1]
+}
+
+func (x SublocalityType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SublocalityType.Descriptor instead.
+func (SublocalityType) EnumDescriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{1}
+}
+
+// LocalityType represents the locality type.
+type LocalityType int32
+
+const (
+	LocalityType_LOCALITY_TYPE_UNSPECIFIED LocalityType = 0
+	LocalityType_LOCALITY_TYPE_CITY        LocalityType = 1
+	LocalityType_LOCALITY_TYPE_DISTRICT    LocalityType = 2
+	LocalityType_LOCALITY_TYPE_POST_TOWN   LocalityType = 3
+	LocalityType_LOCALITY_TYPE_SUBURB      LocalityType = 4
+	LocalityType_LOCALITY_TYPE_TOWN_CITY   LocalityType = 5
+)
+
+// Enum value maps for LocalityType.
+var (
+	LocalityType_name = map[int32]string{
+		0: "LOCALITY_TYPE_UNSPECIFIED",
+		1: "LOCALITY_TYPE_CITY",
+		2: "LOCALITY_TYPE_DISTRICT",
+		3: "LOCALITY_TYPE_POST_TOWN",
+		4: "LOCALITY_TYPE_SUBURB",
+		5: "LOCALITY_TYPE_TOWN_CITY",
+	}
+	LocalityType_value = map[string]int32{
+		"LOCALITY_TYPE_UNSPECIFIED": 0,
+		"LOCALITY_TYPE_CITY":        1,
+		"LOCALITY_TYPE_DISTRICT":    2,
+		"LOCALITY_TYPE_POST_TOWN":   3,
+		"LOCALITY_TYPE_SUBURB":      4,
+		"LOCALITY_TYPE_TOWN_CITY":   5,
+	}
+)
+
+func (x LocalityType) Enum() *LocalityType {
+	p := new(LocalityType)
+	*p = x
+	return p
+}
+
+func (x LocalityType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LocalityType) Descriptor() protoreflect.EnumDescriptor {
+	return file_address_proto_enumTypes[2].Descriptor()
+}
+
+func (LocalityType) Type() protoreflect.EnumType {
+	return &file_address_proto_enumTypes[2]
+}
+
+func (x LocalityType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LocalityType.Descriptor instead.
+func (LocalityType) EnumDescriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{2}
+}
+
+// RegionType represents the region type.
+type RegionType int32
+
+const (
+	RegionType_REGION_TYPE_UNSPECIFIED RegionType = 0
+	RegionType_REGION_TYPE_PROVINCE    RegionType = 1
+	RegionType_REGION_TYPE_AREA        RegionType = 2
+	RegionType_REGION_TYPE_CANTON      RegionType = 3
+	RegionType_REGION_TYPE_COUNTY      RegionType = 4
+	RegionType_REGION_TYPE_DEPARTMENT  RegionType = 5
+	RegionType_REGION_TYPE_DISTRICT    RegionType = 6
+	RegionType_REGION_TYPE_DO_SI       RegionType = 7
+	RegionType_REGION_TYPE_EMIRATE     RegionType = 8
+	RegionType_REGION_TYPE_ISLAND      RegionType = 9
+	RegionType_REGION_TYPE_PARISH      RegionType = 10
+	RegionType_REGION_TYPE_PREFECTURE  RegionType = 11
+	RegionType_REGION_TYPE_REGION      RegionType = 12
+	RegionType_REGION_TYPE_STATE       RegionType = 13
+)
+
+// Enum value maps for RegionType.
+var (
+	RegionType_name = map[int32]string{
+		0:  "REGION_TYPE_UNSPECIFIED",
+		1:  "REGION_TYPE_PROVINCE",
+		2:  "REGION_TYPE_AREA",
+		3:  "REGION_TYPE_CANTON",
+		4:  "REGION_TYPE_COUNTY",
+		5:  "REGION_TYPE_DEPARTMENT",
+		6:  "REGION_TYPE_DISTRICT",
+		7:  "REGION_TYPE_DO_SI",
+		8:  "REGION_TYPE_EMIRATE",
+		9:  "REGION_TYPE_ISLAND",
+		10: "REGION_TYPE_PARISH",
+		11: "REGION_TYPE_PREFECTURE",
+		12: "REGION_TYPE_REGION",
+		13: "REGION_TYPE_STATE",
+	}
+	RegionType_value = map[string]int32{
+		"REGION_TYPE_UNSPECIFIED": 0,
+		"REGION_TYPE_PROVINCE":    1,
+		"REGION_TYPE_AREA":        2,
+		"REGION_TYPE_CANTON":      3,
+		"REGION_TYPE_COUNTY":      4,
+		"REGION_TYPE_DEPARTMENT":  5,
+		"REGION_TYPE_DISTRICT":    6,
+		"REGION_TYPE_DO_SI":       7,
+		"REGION_TYPE_EMIRATE":     8,
+		"REGION_TYPE_ISLAND":      9,
+		"REGION_TYPE_PARISH":      10,
+		"REGION_TYPE_PREFECTURE":  11,
+		"REGION_TYPE_REGION":      12,
+		"REGION_TYPE_STATE":       13,
+	}
+)
+
+func (x RegionType) Enum() *RegionType {
+	p := new(RegionType)
+	*p = x
+	return p
+}
+
+func (x RegionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RegionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_address_proto_enumTypes[3].Descriptor()
+}
+
+func (RegionType) Type() protoreflect.EnumType {
+	return &file_address_proto_enumTypes[3]
+}
+
+func (x RegionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RegionType.Descriptor instead.
+func (RegionType) EnumDescriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{3}
+}
+
+// PostalCodeType represents the postal code type.
+type PostalCodeType int32
+
+const (
+	PostalCodeType_POSTAL_CODE_TYPE_UNSPECIFIED PostalCodeType = 0
+	PostalCodeType_POSTAL_CODE_TYPE_POSTAL      PostalCodeType = 1
+	PostalCodeType_POSTAL_CODE_TYPE_EIR         PostalCodeType = 2
+	PostalCodeType_POSTAL_CODE_TYPE_PIN         PostalCodeType = 3
+	PostalCodeType_POSTAL_CODE_TYPE_ZIP         PostalCodeType = 4
+)
+
+// Enum value maps for PostalCodeType.
+var (
+	PostalCodeType_name = map[int32]string{
+		0: "POSTAL_CODE_TYPE_UNSPECIFIED",
+		1: "POSTAL_CODE_TYPE_POSTAL",
+		2: "POSTAL_CODE_TYPE_EIR",
+		3: "POSTAL_CODE_TYPE_PIN",
+		4: "POSTAL_CODE_TYPE_ZIP",
+	}
+	PostalCodeType_value = map[string]int32{
+		"POSTAL_CODE_TYPE_UNSPECIFIED": 0,
+		"POSTAL_CODE_TYPE_POSTAL":      1,
+		"POSTAL_CODE_TYPE_EIR":         2,
+		"POSTAL_CODE_TYPE_PIN":         3,
+		"POSTAL_CODE_TYPE_ZIP":         4,
+	}
+)
+
+func (x PostalCodeType) Enum() *PostalCodeType {
+	p := new(PostalCodeType)
+	*p = x
+	return p
+}
+
+func (x PostalCodeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostalCodeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_address_proto_enumTypes[4].Descriptor()
+}
+
+func (PostalCodeType) Type() protoreflect.EnumType {
+	return &file_address_proto_enumTypes[4]
+}
+
+func (x PostalCodeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostalCodeType.Descriptor instead.
+func (PostalCodeType) EnumDescriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{4}
+}
+
+// Address represents an address.
+type Address struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Line1       string                 `protobuf:"bytes,1,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2       string                 `protobuf:"bytes,2,opt,name=line2,proto3" json:"line2,omitempty"`
+	Line3       string                 `protobuf:"bytes,3,opt,name=line3,proto3" json:"line3,omitempty"`
+	Sublocality string                 `protobuf:"bytes,4,opt,name=sublocality,proto3" json:"sublocality,omitempty"`
+	Locality    string                 `protobuf:"bytes,5,opt,name=locality,proto3" json:"locality,omitempty"`
+	Region      string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode  string                 `protobuf:"bytes,7,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	// CLDR country code, e.g. "US".
+	CountryCode   string `protobuf:"bytes,8,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_address_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_address_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Address) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *Address) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *Address) GetLine3() string {
+	if x != nil {
+		return x.Line3
+	}
+	return ""
+}
+
+func (x *Address) GetSublocality() string {
+	if x != nil {
+		return x.Sublocality
+	}
+	return ""
+}
+
+func (x *Address) GetLocality() string {
+	if x != nil {
+		return x.Locality
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+// Locale represents a Unicode locale identifier.
+type Locale struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Language      string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Script        string                 `protobuf:"bytes,2,opt,name=script,proto3" json:"script,omitempty"`
+	Territory     string                 `protobuf:"bytes,3,opt,name=territory,proto3" json:"territory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Locale) Reset() {
+	*x = Locale{}
+	mi := &file_address_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Locale) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Locale) ProtoMessage() {}
+
+func (x *Locale) ProtoReflect() protoreflect.Message {
+	mi := &file_address_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Locale.ProtoReflect.Descriptor instead.
+func (*Locale) Descriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Locale) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Locale) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+func (x *Locale) GetTerritory() string {
+	if x != nil {
+		return x.Territory
+	}
+	return ""
+}
+
+// Region represents a region of a country.
+type Region struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Region) Reset() {
+	*x = Region{}
+	mi := &file_address_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Region) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Region) ProtoMessage() {}
+
+func (x *Region) ProtoReflect() protoreflect.Message {
+	mi := &file_address_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Region.ProtoReflect.Descriptor instead.
+func (*Region) Descriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Region) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Region) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// FieldDefault represents the default value of a field.
+type FieldDefault struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         Field                  `protobuf:"varint,1,opt,name=field,proto3,enum=bojanz.address.v1.Field" json:"field,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldDefault) Reset() {
+	*x = FieldDefault{}
+	mi := &file_address_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldDefault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldDefault) ProtoMessage() {}
+
+func (x *FieldDefault) ProtoReflect() protoreflect.Message {
+	mi := &file_address_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldDefault.ProtoReflect.Descriptor instead.
+func (*FieldDefault) Descriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FieldDefault) GetField() Field {
+	if x != nil {
+		return x.Field
+	}
+	return Field_FIELD_UNSPECIFIED
+}
+
+func (x *FieldDefault) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// FieldMaxLength represents the maximum length of a field.
+type FieldMaxLength struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         Field                  `protobuf:"varint,1,opt,name=field,proto3,enum=bojanz.address.v1.Field" json:"field,omitempty"`
+	MaxLength     int32                  `protobuf:"varint,2,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldMaxLength) Reset() {
+	*x = FieldMaxLength{}
+	mi := &file_address_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldMaxLength) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldMaxLength) ProtoMessage() {}
+
+func (x *FieldMaxLength) ProtoReflect() protoreflect.Message {
+	mi := &file_address_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldMaxLength.ProtoReflect.Descriptor instead.
+func (*FieldMaxLength) Descriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FieldMaxLength) GetField() Field {
+	if x != nil {
+		return x.Field
+	}
+	return Field_FIELD_UNSPECIFIED
+}
+
+func (x *FieldMaxLength) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+// Format represents an address format.
+//
+// Unspecified enum values map to the zero values of the Go types
+// (e.g. SUBLOCALITY_TYPE_UNSPECIFIED to SublocalityTypeSuburb).
+type Format struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Locale            *Locale                `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	Layout            string                 `protobuf:"bytes,2,opt,name=layout,proto3" json:"layout,omitempty"`
+	LocalLayout       string                 `protobuf:"bytes,3,opt,name=local_layout,json=localLayout,proto3" json:"local_layout,omitempty"`
+	Required          []Field                `protobuf:"varint,4,rep,packed,name=required,proto3,enum=bojanz.address.v1.Field" json:"required,omitempty"`
+	Defaults          []*FieldDefault        `protobuf:"bytes,5,rep,name=defaults,proto3" json:"defaults,omitempty"`
+	SublocalityType   SublocalityType        `protobuf:"varint,6,opt,name=sublocality_type,json=sublocalityType,proto3,enum=bojanz.address.v1.SublocalityType" json:"sublocality_type,omitempty"`
+	LocalityType      LocalityType           `protobuf:"varint,7,opt,name=locality_type,json=localityType,proto3,enum=bojanz.address.v1.LocalityType" json:"locality_type,omitempty"`
+	RegionType        RegionType             `protobuf:"varint,8,opt,name=region_type,json=regionType,proto3,enum=bojanz.address.v1.RegionType" json:"region_type,omitempty"`
+	PostalCodeType    PostalCodeType         `protobuf:"varint,9,opt,name=postal_code_type,json=postalCodeType,proto3,enum=bojanz.address.v1.PostalCodeType" json:"postal_code_type,omitempty"`
+	PostalCodePattern string                 `protobuf:"bytes,10,opt,name=postal_code_pattern,json=postalCodePattern,proto3" json:"postal_code_pattern,omitempty"`
+	ShowRegionId      bool                   `protobuf:"varint,11,opt,name=show_region_id,json=showRegionId,proto3" json:"show_region_id,omitempty"`
+	// Regions, in display order.
+	Regions       []*Region         `protobuf:"bytes,12,rep,name=regions,proto3" json:"regions,omitempty"`
+	LocalRegions  []*Region         `protobuf:"bytes,13,rep,name=local_regions,json=localRegions,proto3" json:"local_regions,omitempty"`
+	MaxLengths    []*FieldMaxLength `protobuf:"bytes,14,rep,name=max_lengths,json=maxLengths,proto3" json:"max_lengths,omitempty"`
+	Scripts       []string          `protobuf:"bytes,15,rep,name=scripts,proto3" json:"scripts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Format) Reset() {
+	*x = Format{}
+	mi := &file_address_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Format) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Format) ProtoMessage() {}
+
+func (x *Format) ProtoReflect() protoreflect.Message {
+	mi := &file_address_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Format.ProtoReflect.Descriptor instead.
+func (*Format) Descriptor() ([]byte, []int) {
+	return file_address_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Format) GetLocale() *Locale {
+	if x != nil {
+		return x.Locale
+	}
+	return nil
+}
+
+func (x *Format) GetLayout() string {
+	if x != nil {
+		return x.Layout
+	}
+	return ""
+}
+
+func (x *Format) GetLocalLayout() string {
+	if x != nil {
+		return x.LocalLayout
+	}
+	return ""
+}
+
+func (x *Format) GetRequired() []Field {
+	if x != nil {
+		return x.Required
+	}
+	return nil
+}
+
+func (x *Format) GetDefaults() []*FieldDefault {
+	if x != nil {
+		return x.Defaults
+	}
+	return nil
+}
+
+func (x *Format) GetSublocalityType() SublocalityType {
+	if x != nil {
+		return x.SublocalityType
+	}
+	return SublocalityType_SUBLOCALITY_TYPE_UNSPECIFIED
+}
+
+func (x *Format) GetLocalityType() LocalityType {
+	if x != nil {
+		return x.LocalityType
+	}
+	return LocalityType_LOCALITY_TYPE_UNSPECIFIED
+}
+
+func (x *Format) GetRegionType() RegionType {
+	if x != nil {
+		return x.RegionType
+	}
+	return RegionType_REGION_TYPE_UNSPECIFIED
+}
+
+func (x *Format) GetPostalCodeType() PostalCodeType {
+	if x != nil {
+		return x.PostalCodeType
+	}
+	return PostalCodeType_POSTAL_CODE_TYPE_UNSPECIFIED
+}
+
+func (x *Format) GetPostalCodePattern() string {
+	if x != nil {
+		return x.PostalCodePattern
+	}
+	return ""
+}
+
+func (x *Format) GetShowRegionId() bool {
+	if x != nil {
+		return x.ShowRegionId
+	}
+	return false
+}
+
+func (x *Format) GetRegions() []*Region {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *Format) GetLocalRegions() []*Region {
+	if x != nil {
+		return x.LocalRegions
+	}
+	return nil
+}
+
+func (x *Format) GetMaxLengths() []*FieldMaxLength {
+	if x != nil {
+		return x.MaxLengths
+	}
+	return nil
+}
+
+func (x *Format) GetScripts() []string {
+	if x != nil {
+		return x.Scripts
+	}
+	return nil
+}
+
+var File_address_proto protoreflect.FileDescriptor
+
+const file_address_proto_rawDesc = "" +
+	"\n" +
+	"\raddress.proto\x12\x11bojanz.address.v1\"\xe5\x01\n" +
+	"\aAddress\x12\x14\n" +
+	"\x05line1\x18\x01 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x02 \x01(\tR\x05line2\x12\x14\n" +
+	"\x05line3\x18\x03 \x01(\tR\x05line3\x12 \n" +
+	"\vsublocality\x18\x04 \x01(\tR\vsublocality\x12\x1a\n" +
+	"\blocality\x18\x05 \x01(\tR\blocality\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12\x1f\n" +
+	"\vpostal_code\x18\a \x01(\tR\n" +
+	"postalCode\x12!\n" +
+	"\fcountry_code\x18\b \x01(\tR\vcountryCode\"Z\n" +
+	"\x06Locale\x12\x1a\n" +
+	"\blanguage\x18\x01 \x01(\tR\blanguage\x12\x16\n" +
+	"\x06script\x18\x02 \x01(\tR\x06script\x12\x1c\n" +
+	"\tterritory\x18\x03 \x01(\tR\tterritory\",\n" +
+	"\x06Region\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"T\n" +
+	"\fFieldDefault\x12.\n" +
+	"\x05field\x18\x01 \x01(\x0e2\x18.bojanz.address.v1.FieldR\x05field\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"_\n" +
+	"\x0eFieldMaxLength\x12.\n" +
+	"\x05field\x18\x01 \x01(\x0e2\x18.bojanz.address.v1.FieldR\x05field\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\x02 \x01(\x05R\tmaxLength\"\xb4\x06\n" +
+	"\x06Format\x121\n" +
+	"\x06locale\x18\x01 \x01(\v2\x19.bojanz.address.v1.LocaleR\x06locale\x12\x16\n" +
+	"\x06layout\x18\x02 \x01(\tR\x06layout\x12!\n" +
+	"\flocal_layout\x18\x03 \x01(\tR\vlocalLayout\x124\n" +
+	"\brequired\x18\x04 \x03(\x0e2\x18.bojanz.address.v1.FieldR\brequired\x12;\n" +
+	"\bdefaults\x18\x05 \x03(\v2\x1f.bojanz.address.v1.FieldDefaultR\bdefaults\x12M\n" +
+	"\x10sublocality_type\x18\x06 \x01(\x0e2\".bojanz.address.v1.SublocalityTypeR\x0fsublocalityType\x12D\n" +
+	"\rlocality_type\x18\a \x01(\x0e2\x1f.bojanz.address.v1.LocalityTypeR\flocalityType\x12>\n" +
+	"\vregion_type\x18\b \x01(\x0e2\x1d.bojanz.address.v1.RegionTypeR\n" +
+	"regionType\x12K\n" +
+	"\x10postal_code_type\x18\t \x01(\x0e2!.bojanz.address.v1.PostalCodeTypeR\x0epostalCodeType\x12.\n" +
+	"\x13postal_code_pattern\x18\n" +
+	" \x01(\tR\x11postalCodePattern\x12$\n" +
+	"\x0eshow_region_id\x18\v \x01(\bR\fshowRegionId\x123\n" +
+	"\aregions\x18\f \x03(\v2\x19.bojanz.address.v1.RegionR\aregions\x12>\n" +
+	"\rlocal_regions\x18\r \x03(\v2\x19.bojanz.address.v1.RegionR\flocalRegions\x12B\n" +
+	"\vmax_lengths\x18\x0e \x03(\v2!.bojanz.address.v1.FieldMaxLengthR\n" +
+	"maxLengths\x12\x18\n" +
+	"\ascripts\x18\x0f \x03(\tR\ascripts*\xa5\x01\n" +
+	"\x05Field\x12\x15\n" +
+	"\x11FIELD_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vFIELD_LINE1\x10\x01\x12\x0f\n" +
+	"\vFIELD_LINE2\x10\x02\x12\x0f\n" +
+	"\vFIELD_LINE3\x10\x03\x12\x15\n" +
+	"\x11FIELD_SUBLOCALITY\x10\x04\x12\x12\n" +
+	"\x0eFIELD_LOCALITY\x10\x05\x12\x10\n" +
+	"\fFIELD_REGION\x10\x06\x12\x15\n" +
+	"\x11FIELD_POSTAL_CODE\x10\a*\xd8\x01\n" +
+	"\x0fSublocalityType\x12 \n" +
+	"\x1cSUBLOCALITY_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SUBLOCALITY_TYPE_SUBURB\x10\x01\x12\x1d\n" +
+	"\x19SUBLOCALITY_TYPE_DISTRICT\x10\x02\x12!\n" +
+	"\x1dSUBLOCALITY_TYPE_NEIGHBORHOOD\x10\x03\x12%\n" +
+	"!SUBLOCALITY_TYPE_VILLAGE_TOWNSHIP\x10\x04\x12\x1d\n" +
+	"\x19SUBLOCALITY_TYPE_TOWNLAND\x10\x05*\xb5\x01\n" +
+	"\fLocalityType\x12\x1d\n" +
+	"\x19LOCALITY_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12LOCALITY_TYPE_CITY\x10\x01\x12\x1a\n" +
+	"\x16LOCALITY_TYPE_DISTRICT\x10\x02\x12\x1b\n" +
+	"\x17LOCALITY_TYPE_POST_TOWN\x10\x03\x12\x18\n" +
+	"\x14LOCALITY_TYPE_SUBURB\x10\x04\x12\x1b\n" +
+	"\x17LOCALITY_TYPE_TOWN_CITY\x10\x05*\xea\x02\n" +
+	"\n" +
+	"RegionType\x12\x1b\n" +
+	"\x17REGION_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14REGION_TYPE_PROVINCE\x10\x01\x12\x14\n" +
+	"\x10REGION_TYPE_AREA\x10\x02\x12\x16\n" +
+	"\x12REGION_TYPE_CANTON\x10\x03\x12\x16\n" +
+	"\x12REGION_TYPE_COUNTY\x10\x04\x12\x1a\n" +
+	"\x16REGION_TYPE_DEPARTMENT\x10\x05\x12\x18\n" +
+	"\x14REGION_TYPE_DISTRICT\x10\x06\x12\x15\n" +
+	"\x11REGION_TYPE_DO_SI\x10\a\x12\x17\n" +
+	"\x13REGION_TYPE_EMIRATE\x10\b\x12\x16\n" +
+	"\x12REGION_TYPE_ISLAND\x10\t\x12\x16\n" +
+	"\x12REGION_TYPE_PARISH\x10\n" +
+	"\x12\x1a\n" +
+	"\x16REGION_TYPE_PREFECTURE\x10\v\x12\x16\n" +
+	"\x12REGION_TYPE_REGION\x10\f\x12\x15\n" +
+	"\x11REGION_TYPE_STATE\x10\r*\x9d\x01\n" +
+	"\x0ePostalCodeType\x12 \n" +
+	"\x1cPOSTAL_CODE_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17POSTAL_CODE_TYPE_POSTAL\x10\x01\x12\x18\n" +
+	"\x14POSTAL_CODE_TYPE_EIR\x10\x02\x12\x18\n" +
+	"\x14POSTAL_CODE_TYPE_PIN\x10\x03\x12\x18\n" +
+	"\x14POSTAL_CODE_TYPE_ZIP\x10\x04B%Z#github.com/bojanz/address/addresspbb\x06proto3"
+
+var (
+	file_address_proto_rawDescOnce sync.Once
+	file_address_proto_rawDescData []byte
+)
+
+func file_address_proto_rawDescGZIP() []byte {
+	file_address_proto_rawDescOnce.Do(func() {
+		file_address_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_address_proto_rawDesc), len(file_address_proto_rawDesc)))
+	})
+	return file_address_proto_rawDescData
+}
+
+var file_address_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_address_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_address_proto_goTypes = []any{
+	(Field)(0),             // 0: bojanz.address.v1.Field
+	(SublocalityType)(0),   // 1: bojanz.address.v1.SublocalityType
+	(LocalityType)(0),      // 2: bojanz.address.v1.LocalityType
+	(RegionType)(0),        // 3: bojanz.address.v1.RegionType
+	(PostalCodeType)(0),    // 4: bojanz.address.v1.PostalCodeType
+	(*Address)(nil),        // 5: bojanz.address.v1.Address
+	(*Locale)(nil),         // 6: bojanz.address.v1.Locale
+	(*Region)(nil),         // 7: bojanz.address.v1.Region
+	(*FieldDefault)(nil),   // 8: bojanz.address.v1.FieldDefault
+	(*FieldMaxLength)(nil), // 9: bojanz.address.v1.FieldMaxLength
+	(*Format)(nil),         // 10: bojanz.address.v1.Format
+}
+var file_address_proto_depIdxs = []int32{
+	0,  // 0: bojanz.address.v1.FieldDefault.field:type_name -> bojanz.address.v1.Field
+	0,  // 1: bojanz.address.v1.FieldMaxLength.field:type_name -> bojanz.address.v1.Field
+	6,  // 2: bojanz.address.v1.Format.locale:type_name -> bojanz.address.v1.Locale
+	0,  // 3: bojanz.address.v1.Format.required:type_name -> bojanz.address.v1.Field
+	8,  // 4: bojanz.address.v1.Format.defaults:type_name -> bojanz.address.v1.FieldDefault
+	1,  // 5: bojanz.address.v1.Format.sublocality_type:type_name -> bojanz.address.v1.SublocalityType
+	2,  // 6: bojanz.address.v1.Format.locality_type:type_name -> bojanz.address.v1.LocalityType
+	3,  // 7: bojanz.address.v1.Format.region_type:type_name -> bojanz.address.v1.RegionType
+	4,  // 8: bojanz.address.v1.Format.postal_code_type:type_name -> bojanz.address.v1.PostalCodeType
+	7,  // 9: bojanz.address.v1.Format.regions:type_name -> bojanz.address.v1.Region
+	7,  // 10: bojanz.address.v1.Format.local_regions:type_name -> bojanz.address.v1.Region
+	9,  // 11: bojanz.address.v1.Format.max_lengths:type_name -> bojanz.address.v1.FieldMaxLength
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_address_proto_init() }
+func file_address_proto_init() {
+	if File_address_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_address_proto_rawDesc), len(file_address_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_address_proto_goTypes,
+		DependencyIndexes: file_address_proto_depIdxs,
+		EnumInfos:         file_address_proto_enumTypes,
+		MessageInfos:      file_address_proto_msgTypes,
+	}.Build()
+	File_address_proto = out.File
+	file_address_proto_goTypes = nil
+	file_address_proto_depIdxs = nil
+}
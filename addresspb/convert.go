@@ -0,0 +1,257 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package addresspb
+
+import "github.com/bojanz/address"
+
+// fields are the address fields, in the order of the Field enum values.
+var fields = [...]address.Field{
+	address.FieldLine1,
+	address.FieldLine2,
+	address.FieldLine3,
+	address.FieldSublocality,
+	address.FieldLocality,
+	address.FieldRegion,
+	address.FieldPostalCode,
+}
+
+// FromAddress converts an address.Address to an Address message.
+func FromAddress(a address.Address) *Address {
+	return &Address{
+		Line1:       a.Line1,
+		Line2:       a.Line2,
+		Line3:       a.Line3,
+		Sublocality: a.Sublocality,
+		Locality:    a.Locality,
+		Region:      a.Region,
+		PostalCode:  a.PostalCode,
+		CountryCode: a.CountryCode,
+	}
+}
+
+// ToAddress converts an Address message to an address.Address.
+//
+// A nil message is converted to an empty address.
+func ToAddress(x *Address) address.Address {
+	return address.Address{
+		Line1:       x.GetLine1(),
+		Line2:       x.GetLine2(),
+		Line3:       x.GetLine3(),
+		Sublocality: x.GetSublocality(),
+		Locality:    x.GetLocality(),
+		Region:      x.GetRegion(),
+		PostalCode:  x.GetPostalCode(),
+		CountryCode: x.GetCountryCode(),
+	}
+}
+
+// FromLocale converts an address.Locale to a Locale message.
+//
+// An empty locale is converted to nil.
+func FromLocale(l address.Locale) *Locale {
+	if l.IsEmpty() {
+		return nil
+	}
+	return &Locale{
+		Language:  l.Language,
+		Script:    l.Script,
+		Territory: l.Territory,
+	}
+}
+
+// ToLocale converts a Locale message to an address.Locale.
+func ToLocale(x *Locale) address.Locale {
+	return address.Locale{
+		Language:  x.GetLanguage(),
+		Script:    x.GetScript(),
+		Territory: x.GetTerritory(),
+	}
+}
+
+// FromField converts an address.Field to a Field enum value.
+//
+// Unknown fields are converted to Field_FIELD_UNSPECIFIED.
+func FromField(f address.Field) Field {
+	for i, field := range fields {
+		if field == f {
+			return Field(i + 1)
+		}
+	}
+	return Field_FIELD_UNSPECIFIED
+}
+
+// ToField converts a Field enum value to an address.Field.
+//
+// Unspecified and unknown values are converted to an empty string.
+func ToField(x Field) address.Field {
+	if x <= 0 || int(x) > len(fields) {
+		return ""
+	}
+	return fields[x-1]
+}
+
+// FromSublocalityType converts an address.SublocalityType to a SublocalityType enum value.
+func FromSublocalityType(t address.SublocalityType) SublocalityType {
+	return SublocalityType(fromEnum(uint8(t), SublocalityType_name))
+}
+
+// ToSublocalityType converts a SublocalityType enum value to an address.SublocalityType.
+//
+// Unspecified and unknown values are converted to the default (suburb).
+func ToSublocalityType(x SublocalityType) address.SublocalityType {
+	return address.SublocalityType(toEnum(int32(x), SublocalityType_name))
+}
+
+// FromLocalityType converts an address.LocalityType to a LocalityType enum value.
+func FromLocalityType(t address.LocalityType) LocalityType {
+	return LocalityType(fromEnum(uint8(t), LocalityType_name))
+}
+
+// ToLocalityType converts a LocalityType enum value to an address.LocalityType.
+//
+// Unspecified and unknown values are converted to the default (city).
+func ToLocalityType(x LocalityType) address.LocalityType {
+	return address.LocalityType(toEnum(int32(x), LocalityType_name))
+}
+
+// FromRegionType converts an address.RegionType to a RegionType enum value.
+func FromRegionType(t address.RegionType) RegionType {
+	return RegionType(fromEnum(uint8(t), RegionType_name))
+}
+
+// ToRegionType converts a RegionType enum value to an address.RegionType.
+//
+// Unspecified and unknown values are converted to the default (province).
+func ToRegionType(x RegionType) address.RegionType {
+	return address.RegionType(toEnum(int32(x), RegionType_name))
+}
+
+// FromPostalCodeType converts an address.PostalCodeType to a PostalCodeType enum value.
+func FromPostalCodeType(t address.PostalCodeType) PostalCodeType {
+	return PostalCodeType(fromEnum(uint8(t), PostalCodeType_name))
+}
+
+// ToPostalCodeType converts a PostalCodeType enum value to an address.PostalCodeType.
+//
+// Unspecified and unknown values are converted to the default (postal).
+func ToPostalCodeType(x PostalCodeType) address.PostalCodeType {
+	return address.PostalCodeType(toEnum(int32(x), PostalCodeType_name))
+}
+
+// FromFormat converts an address.Format to a Format message.
+//
+// Defaults and maximum lengths are sorted by field.
+func FromFormat(f address.Format) *Format {
+	x := &Format{
+		Locale:            FromLocale(f.Locale),
+		Layout:            f.Layout,
+		LocalLayout:       f.LocalLayout,
+		SublocalityType:   FromSublocalityType(f.SublocalityType),
+		LocalityType:      FromLocalityType(f.LocalityType),
+		RegionType:        FromRegionType(f.RegionType),
+		PostalCodeType:    FromPostalCodeType(f.PostalCodeType),
+		PostalCodePattern: f.PostalCodePattern,
+		ShowRegionId:      f.ShowRegionID,
+		Regions:           fromRegionMap(f.Regions),
+		LocalRegions:      fromRegionMap(f.LocalRegions),
+		Scripts:           f.Scripts,
+	}
+	for _, field := range f.Required {
+		x.Required = append(x.Required, FromField(field))
+	}
+	for _, field := range fields {
+		if value, ok := f.Defaults[field]; ok {
+			x.Defaults = append(x.Defaults, &FieldDefault{Field: FromField(field), Value: value})
+		}
+		if maxLength, ok := f.MaxLengths[field]; ok {
+			x.MaxLengths = append(x.MaxLengths, &FieldMaxLength{Field: FromField(field), MaxLength: int32(maxLength)})
+		}
+	}
+	return x
+}
+
+// ToFormat converts a Format message to an address.Format.
+//
+// Unspecified and unknown fields are skipped.
+func ToFormat(x *Format) address.Format {
+	f := address.Format{
+		Locale:            ToLocale(x.GetLocale()),
+		Layout:            x.GetLayout(),
+		LocalLayout:       x.GetLocalLayout(),
+		SublocalityType:   ToSublocalityType(x.GetSublocalityType()),
+		LocalityType:      ToLocalityType(x.GetLocalityType()),
+		RegionType:        ToRegionType(x.GetRegionType()),
+		PostalCodeType:    ToPostalCodeType(x.GetPostalCodeType()),
+		PostalCodePattern: x.GetPostalCodePattern(),
+		ShowRegionID:      x.GetShowRegionId(),
+		Regions:           toRegionMap(x.GetRegions()),
+		LocalRegions:      toRegionMap(x.GetLocalRegions()),
+		Scripts:           x.GetScripts(),
+	}
+	for _, value := range x.GetRequired() {
+		if field := ToField(value); field != "" {
+			f.Required = append(f.Required, field)
+		}
+	}
+	for _, d := range x.GetDefaults() {
+		if field := ToField(d.GetField()); field != "" {
+			if f.Defaults == nil {
+				f.Defaults = make(map[address.Field]string)
+			}
+			f.Defaults[field] = d.GetValue()
+		}
+	}
+	for _, m := range x.GetMaxLengths() {
+		if field := ToField(m.GetField()); field != "" {
+			if f.MaxLengths == nil {
+				f.MaxLengths = make(map[address.Field]int)
+			}
+			f.MaxLengths[field] = int(m.GetMaxLength())
+		}
+	}
+	return f
+}
+
+// fromRegionMap converts a region map to Region messages, preserving order.
+func fromRegionMap(r address.RegionMap) []*Region {
+	if r.Len() == 0 {
+		return nil
+	}
+	regions := make([]*Region, 0, r.Len())
+	for _, key := range r.Keys() {
+		name, _ := r.Get(key)
+		regions = append(regions, &Region{Id: key, Name: name})
+	}
+	return regions
+}
+
+// toRegionMap converts Region messages to a region map.
+func toRegionMap(regions []*Region) address.RegionMap {
+	if len(regions) == 0 {
+		return address.RegionMap{}
+	}
+	pairs := make([]string, 0, len(regions)*2)
+	for _, region := range regions {
+		pairs = append(pairs, region.GetId(), region.GetName())
+	}
+	return address.NewRegionMap(pairs...)
+}
+
+// fromEnum converts a Go enum value to a protobuf enum value.
+//
+// Protobuf enum values are offset by one, to reserve zero for the unspecified value.
+func fromEnum(value uint8, names map[int32]string) int32 {
+	if _, ok := names[int32(value)+1]; !ok {
+		return 0
+	}
+	return int32(value) + 1
+}
+
+// toEnum converts a protobuf enum value to a Go enum value.
+func toEnum(value int32, names map[int32]string) uint8 {
+	if _, ok := names[value]; !ok || value == 0 {
+		return 0
+	}
+	return uint8(value - 1)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package addresspb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bojanz/address"
+	"github.com/bojanz/address/addresspb"
+	"google.golang.org/protobuf/proto"
+)
+
+// roundTrip marshals the given message to the wire format and back.
+func roundTrip[M proto.Message](t *testing.T, x M, out M) M {
+	t.Helper()
+	data, err := proto.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := proto.Unmarshal(data, out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestAddress(t *testing.T) {
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Line2:       "Suite 200",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	x := addresspb.FromAddress(addr)
+	if x.GetPostalCode() != "94043" || x.GetCountryCode() != "US" {
+		t.Errorf("got %v, want the address fields", x)
+	}
+	got := addresspb.ToAddress(roundTrip(t, x, &addresspb.Address{}))
+	if got != addr {
+		t.Errorf("got %v, want %v", got, addr)
+	}
+
+	// Nil messages.
+	if got := addresspb.ToAddress(nil); !got.IsEmpty() {
+		t.Errorf("got %v, want an empty address", got)
+	}
+	if got := addresspb.ToLocale(nil); !got.IsEmpty() {
+		t.Errorf("got %v, want an empty locale", got)
+	}
+}
+
+func TestLocale(t *testing.T) {
+	locale := address.NewLocale("sr-Latn-RS")
+	got := addresspb.ToLocale(addresspb.FromLocale(locale))
+	if got != locale {
+		t.Errorf("got %v, want %v", got, locale)
+	}
+	if x := addresspb.FromLocale(address.Locale{}); x != nil {
+		t.Errorf("got %v, want nil", x)
+	}
+}
+
+func TestField(t *testing.T) {
+	tests := []struct {
+		field address.Field
+		want  addresspb.Field
+	}{
+		{address.FieldLine1, addresspb.Field_FIELD_LINE1},
+		{address.FieldSublocality, addresspb.Field_FIELD_SUBLOCALITY},
+		{address.FieldPostalCode, addresspb.Field_FIELD_POSTAL_CODE},
+		{address.Field("X"), addresspb.Field_FIELD_UNSPECIFIED},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.field), func(t *testing.T) {
+			got := addresspb.FromField(tt.field)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := addresspb.ToField(addresspb.Field_FIELD_REGION); got != address.FieldRegion {
+		t.Errorf("got %v, want %v", got, address.FieldRegion)
+	}
+	if got := addresspb.ToField(addresspb.Field(99)); got != "" {
+		t.Errorf("got %v, want an empty field", got)
+	}
+}
+
+func TestEnums(t *testing.T) {
+	if got := addresspb.FromSublocalityType(address.SublocalityTypeSuburb); got != addresspb.SublocalityType_SUBLOCALITY_TYPE_SUBURB {
+		t.Errorf("got %v, want SUBLOCALITY_TYPE_SUBURB", got)
+	}
+	if got := addresspb.FromLocalityType(address.LocalityTypePostTown); got != addresspb.LocalityType_LOCALITY_TYPE_POST_TOWN {
+		t.Errorf("got %v, want LOCALITY_TYPE_POST_TOWN", got)
+	}
+	if got := addresspb.FromRegionType(address.RegionTypeDoSi); got != addresspb.RegionType_REGION_TYPE_DO_SI {
+		t.Errorf("got %v, want REGION_TYPE_DO_SI", got)
+	}
+	if got := addresspb.FromPostalCodeType(address.PostalCodeTypeZip); got != addresspb.PostalCodeType_POSTAL_CODE_TYPE_ZIP {
+		t.Errorf("got %v, want POSTAL_CODE_TYPE_ZIP", got)
+	}
+	if got := addresspb.ToRegionType(addresspb.RegionType_REGION_TYPE_STATE); got != address.RegionTypeState {
+		t.Errorf("got %v, want state", got)
+	}
+	// Unspecified and unknown values use the default.
+	for _, x := range []addresspb.PostalCodeType{addresspb.PostalCodeType_POSTAL_CODE_TYPE_UNSPECIFIED, addresspb.PostalCodeType(99)} {
+		if got := addresspb.ToPostalCodeType(x); got != address.PostalCodeTypePostal {
+			t.Errorf("%v: got %v, want postal", x, got)
+		}
+	}
+	if got := addresspb.RegionType(99).String(); got != "99" {
+		t.Errorf("got %v, want 99", got)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for countryCode, format := range address.GetFormats() {
+		got := addresspb.ToFormat(roundTrip(t, addresspb.FromFormat(format), &addresspb.Format{}))
+		if !reflect.DeepEqual(got, format) {
+			t.Errorf("%v: got %#v, want %#v", countryCode, got, format)
+		}
+	}
+
+	format := address.Format{
+		Layout:     "%1\n%2\n%L",
+		Required:   []address.Field{address.FieldLine1, address.FieldLocality},
+		Defaults:   map[address.Field]string{address.FieldLocality: "Singapore"},
+		MaxLengths: map[address.Field]int{address.FieldLine2: 30, address.FieldLine1: 35},
+		Scripts:    []string{"Latn"},
+	}
+	x := addresspb.FromFormat(format)
+	wantMaxLengths := []*addresspb.FieldMaxLength{
+		{Field: addresspb.Field_FIELD_LINE1, MaxLength: 35},
+		{Field: addresspb.Field_FIELD_LINE2, MaxLength: 30},
+	}
+	if len(x.MaxLengths) != len(wantMaxLengths) {
+		t.Fatalf("got %v, want %v", x.MaxLengths, wantMaxLengths)
+	}
+	for i, m := range x.MaxLengths {
+		if !proto.Equal(m, wantMaxLengths[i]) {
+			t.Errorf("got %v, want %v", m, wantMaxLengths[i])
+		}
+	}
+	got := addresspb.ToFormat(x)
+	if !reflect.DeepEqual(got, format) {
+		t.Errorf("got %#v, want %#v", got, format)
+	}
+
+	if got := addresspb.ToFormat(nil); got.Layout != "" || got.Required != nil {
+		t.Errorf("got %#v, want an empty format", got)
+	}
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+// Package addresspb provides the protobuf representation of addresses and address formats.
+//
+// The message and enum types are generated from address.proto by protoc-gen-go,
+// and can be marshalled using the protobuf runtime and used with gRPC.
+// The package is a separate module, to keep the address package free of dependencies.
+package addresspb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative address.proto
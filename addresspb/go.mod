@@ -0,0 +1,10 @@
+module github.com/bojanz/address/addresspb
+
+go 1.23
+
+require (
+	github.com/bojanz/address v0.0.0
+	google.golang.org/protobuf v1.36.11
+)
+
+replace github.com/bojanz/address => ../
@@ -0,0 +1,162 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// CarrierProfile describes the address fields accepted by a carrier API.
+type CarrierProfile struct {
+	// Lines is the number of street address lines.
+	Lines int
+	// MaxLength is the maximum number of characters per line, or 0 if unlimited.
+	MaxLength int
+	// LocalityMaxLength is the maximum number of characters in the locality, or 0 if unlimited.
+	LocalityMaxLength int
+	// Uppercase converts all values to uppercase.
+	Uppercase bool
+	// RegionCode uses the region ID (e.g. "CA") instead of the region name.
+	RegionCode bool
+	// Registry provides the address formats, used for resolving regions.
+	// Defaults to nil, in which case the built-in formats are used.
+	Registry *Registry
+}
+
+// Predefined carrier profiles.
+var (
+	CarrierProfileUPS   = CarrierProfile{Lines: 3, MaxLength: 35, LocalityMaxLength: 30, RegionCode: true}
+	CarrierProfileFedEx = CarrierProfile{Lines: 2, MaxLength: 35, LocalityMaxLength: 35, RegionCode: true}
+	CarrierProfileDHL   = CarrierProfile{Lines: 3, MaxLength: 35, LocalityMaxLength: 35, RegionCode: true}
+)
+
+// CarrierAddress represents an address mapped to a carrier profile.
+type CarrierAddress struct {
+	Lines       []string `json:"lines"`
+	Locality    string   `json:"locality"`
+	Region      string   `json:"region"`
+	PostalCode  string   `json:"postal_code"`
+	CountryCode string   `json:"country"`
+}
+
+// Map maps the given address to the carrier profile.
+//
+// The address lines and the sublocality are folded into the available
+// street lines. Each value keeps its own line if possible, otherwise values
+// are joined with ", " and wrapped at word boundaries. Words longer than
+// MaxLength are split. An error is returned if the result does not fit.
+func (p CarrierProfile) Map(addr Address) (CarrierAddress, error) {
+	if p.Lines < 1 {
+		return CarrierAddress{}, fmt.Errorf("invalid carrier profile: %d lines", p.Lines)
+	}
+	format := p.getFormat(addr.CountryCode)
+	var values []string
+	for _, value := range []string{addr.Line1, addr.Line2, addr.Line3, addr.Sublocality} {
+		if value = p.normalize(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	lines, ok := p.fitLines(values)
+	if !ok {
+		return CarrierAddress{}, fmt.Errorf("address does not fit in %d lines of %d characters", p.Lines, p.MaxLength)
+	}
+	ca := CarrierAddress{
+		Lines:       lines,
+		Locality:    p.normalize(addr.Locality),
+		PostalCode:  p.normalize(addr.PostalCode),
+		CountryCode: addr.CountryCode,
+	}
+	if p.LocalityMaxLength > 0 && utf8.RuneCountInString(ca.Locality) > p.LocalityMaxLength {
+		return CarrierAddress{}, fmt.Errorf("locality %q is longer than %d characters", ca.Locality, p.LocalityMaxLength)
+	}
+	region := lookupRegion(format, addr.Region)
+	if !p.RegionCode {
+		if name, ok := format.Regions.Get(region); ok {
+			region = name
+		}
+	}
+	ca.Region = p.normalize(region)
+
+	return ca, nil
+}
+
+// getFormat returns the address format for the given country code.
+func (p CarrierProfile) getFormat(countryCode string) Format {
+	if p.Registry != nil {
+		return p.Registry.Get(countryCode)
+	}
+	return GetFormat(countryCode)
+}
+
+// normalize trims and collapses whitespace in the given value,
+// uppercasing it if needed.
+func (p CarrierProfile) normalize(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if p.Uppercase {
+		value = strings.ToUpper(value)
+	}
+	return value
+}
+
+// fitLines fits the given values into the available lines.
+func (p CarrierProfile) fitLines(values []string) ([]string, bool) {
+	if p.MaxLength == 0 {
+		if len(values) > p.Lines {
+			last := strings.Join(values[p.Lines-1:], ", ")
+			values = append(values[:p.Lines-1:p.Lines-1], last)
+		}
+		return values, true
+	}
+	// Try keeping each value on its own line(s) first.
+	var lines []string
+	for _, value := range values {
+		lines = append(lines, wrapWords(value, p.MaxLength)...)
+	}
+	if len(lines) <= p.Lines {
+		return lines, true
+	}
+	lines = wrapWords(strings.Join(values, ", "), p.MaxLength)
+	if len(lines) <= p.Lines {
+		// Remove separators left at the end of wrapped lines.
+		for i, line := range lines {
+			lines[i] = strings.TrimSuffix(line, ",")
+		}
+		return lines, true
+	}
+	return nil, false
+}
+
+// wrapWords wraps the given text into lines of at most maxLength characters.
+//
+// Text is wrapped at spaces, splitting words longer than maxLength.
+func wrapWords(text string, maxLength int) []string {
+	var lines []string
+	line := ""
+	lineLength := 0
+	for _, word := range strings.Fields(text) {
+		wordLength := utf8.RuneCountInString(word)
+		if lineLength > 0 && lineLength+1+wordLength <= maxLength {
+			line += " " + word
+			lineLength += 1 + wordLength
+			continue
+		}
+		if lineLength > 0 {
+			lines = append(lines, line)
+		}
+		for wordLength > maxLength {
+			runes := []rune(word)
+			lines = append(lines, string(runes[:maxLength]))
+			word = string(runes[maxLength:])
+			wordLength -= maxLength
+		}
+		line, lineLength = word, wordLength
+	}
+	if lineLength > 0 {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
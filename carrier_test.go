@@ -0,0 +1,152 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestCarrierProfile_Map(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile address.CarrierProfile
+		addr    address.Address
+		want    address.CarrierAddress
+		wantErr bool
+	}{
+		{
+			"values on their own lines",
+			address.CarrierProfileUPS,
+			address.Address{
+				Line1:       "1098 Alta Ave",
+				Line2:       "Suite 100",
+				Locality:    "Mountain View",
+				Region:      "CA",
+				PostalCode:  "94043",
+				CountryCode: "US",
+			},
+			address.CarrierAddress{
+				Lines:       []string{"1098 Alta Ave", "Suite 100"},
+				Locality:    "Mountain View",
+				Region:      "CA",
+				PostalCode:  "94043",
+				CountryCode: "US",
+			},
+			false,
+		},
+		{
+			"sublocality folded into lines",
+			address.CarrierProfileFedEx,
+			address.Address{
+				Line1:       "Xing Fu Zhong Lu",
+				Line2:       "Building 5",
+				Sublocality: "Xincheng Qu",
+				Locality:    "Xi'an Shi",
+				Region:      "SN",
+				PostalCode:  "710043",
+				CountryCode: "CN",
+			},
+			address.CarrierAddress{
+				Lines:       []string{"Xing Fu Zhong Lu, Building 5", "Xincheng Qu"},
+				Locality:    "Xi'an Shi",
+				Region:      "SN",
+				PostalCode:  "710043",
+				CountryCode: "CN",
+			},
+			false,
+		},
+		{
+			"long line wrapped at word boundaries",
+			address.CarrierProfile{Lines: 2, MaxLength: 20, Uppercase: true},
+			address.Address{
+				Line1:       "Avenida Paulista 1578 Bela Vista",
+				Locality:    "São Paulo",
+				Region:      "SP",
+				PostalCode:  "01310-200",
+				CountryCode: "BR",
+			},
+			address.CarrierAddress{
+				Lines:       []string{"AVENIDA PAULISTA", "1578 BELA VISTA"},
+				Locality:    "SÃO PAULO",
+				Region:      "SÃO PAULO",
+				PostalCode:  "01310-200",
+				CountryCode: "BR",
+			},
+			false,
+		},
+		{
+			"region name converted to code",
+			address.CarrierProfile{Lines: 1, RegionCode: true},
+			address.Address{
+				Line1:       "1098 Alta Ave",
+				Line2:       "Suite 100",
+				Line3:       "Attn: Receiving",
+				Locality:    "Mountain View",
+				Region:      "California",
+				PostalCode:  "94043",
+				CountryCode: "US",
+			},
+			address.CarrierAddress{
+				Lines:       []string{"1098 Alta Ave, Suite 100, Attn: Receiving"},
+				Locality:    "Mountain View",
+				Region:      "CA",
+				PostalCode:  "94043",
+				CountryCode: "US",
+			},
+			false,
+		},
+		{
+			"address too long",
+			address.CarrierProfile{Lines: 1, MaxLength: 20},
+			address.Address{
+				Line1:       "1098 Alta Ave",
+				Line2:       "Suite 100",
+				CountryCode: "US",
+			},
+			address.CarrierAddress{},
+			true,
+		},
+		{
+			"locality too long",
+			address.CarrierProfileUPS,
+			address.Address{
+				Line1:       "Church Lane",
+				Locality:    "Llanfairpwllgwyngyllgogerychwyrndrobwllllantysiliogogogoch",
+				PostalCode:  "LL61 5UJ",
+				CountryCode: "GB",
+			},
+			address.CarrierAddress{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.profile.Map(tt.addr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCarrierProfile_Map_Registry(t *testing.T) {
+	r := address.NewRegistry()
+	r.SetRegions("RS", address.NewRegionMap("VO", "Vojvodina"), address.RegionMap{})
+	profile := address.CarrierProfileFedEx
+	profile.Registry = r
+	profile.RegionCode = false
+	got, err := profile.Map(address.Address{Line1: "Bulevar oslobođenja 1", Locality: "Novi Sad", Region: "VO", CountryCode: "RS"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Region != "Vojvodina" {
+		t.Errorf("got %q, want %q", got.Region, "Vojvodina")
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AddressType represents the type of an address.
+type AddressType uint8
+
+const (
+	AddressTypeStreet AddressType = iota
+	AddressTypePOBox
+	AddressTypeMilitary
+	AddressTypeParcelLocker
+)
+
+var addressTypeNames = [...]string{"street", "po_box", "military", "parcel_locker"}
+
+// String returns the string representation of t.
+func (t AddressType) String() string {
+	if int(t) >= len(addressTypeNames) {
+		return ""
+	}
+	return addressTypeNames[t]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t AddressType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *AddressType) UnmarshalText(b []byte) error {
+	aux := string(b)
+	for i, name := range addressTypeNames {
+		if name == aux {
+			*t = AddressType(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid address type %q", aux)
+}
+
+// Classify returns the type of the address.
+//
+// US military addresses are detected by their Armed Forces region (AA, AE, AP)
+// or their APO/FPO/DPO locality. Parcel lockers (e.g. Packstation, Paczkomat)
+// and PO boxes are detected by matching the address lines against patterns
+// in the languages of the address country, as well as English.
+// All other addresses are considered street addresses.
+func (a Address) Classify() AddressType {
+	if a.isMilitary() {
+		return AddressTypeMilitary
+	}
+	lines := []string{a.Line1, a.Line2, a.Line3}
+	for _, line := range lines {
+		if line != "" && parcelLockerPattern.MatchString(line) {
+			return AddressTypeParcelLocker
+		}
+	}
+	patterns := append([]*regexp.Regexp{poBoxPatterns["en"]}, poBoxPatternsByCountry(a.CountryCode)...)
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		for _, pattern := range patterns {
+			if pattern.MatchString(line) {
+				return AddressTypePOBox
+			}
+		}
+	}
+	return AddressTypeStreet
+}
+
+// isMilitary returns whether the address is a US military address.
+func (a Address) isMilitary() bool {
+	if a.CountryCode != "US" {
+		return false
+	}
+	switch strings.ToUpper(a.Region) {
+	case "AA", "AE", "AP":
+		return true
+	}
+	switch strings.ToUpper(strings.TrimSpace(a.Locality)) {
+	case "APO", "FPO", "DPO":
+		return true
+	}
+	return false
+}
+
+// poBoxPatternsByCountry returns the PO box patterns for the given country.
+func poBoxPatternsByCountry(countryCode string) []*regexp.Regexp {
+	languages := poBoxLanguages[countryCode]
+	patterns := make([]*regexp.Regexp, 0, len(languages))
+	for _, language := range languages {
+		patterns = append(patterns, poBoxPatterns[language])
+	}
+	return patterns
+}
+
+// parcelLockerPattern matches parcel locker addresses.
+var parcelLockerPattern = regexp.MustCompile(`(?i)\b(packstation|paczkomat|parcel\s*locker|amazon\s+(hub\s+)?locker|pakkeboks|pakettiautomaatti|paketautomat)\b`)
+
+// poBoxPatterns are the PO box patterns, keyed by language.
+var poBoxPatterns = map[string]*regexp.Regexp{
+	"en": regexp.MustCompile(`(?i)\b(p\.?\s*o\.?\s*box|post\s+office\s+box|gpo\s+box|private\s+bag|locked\s+bag)\b`),
+	"de": regexp.MustCompile(`(?i)\bpostfach\b`),
+	"fr": regexp.MustCompile(`(?i)(\bbo[iî]te\s+postale\b|\bcase\s+postale\b|\bb\.?\s*p\.?\s*\d)`),
+	"it": regexp.MustCompile(`(?i)(\bcasella\s+postale\b|\bc\.?\s*p\.?\s*\d)`),
+	"es": regexp.MustCompile(`(?i)(\bapartado(\s+(de\s+correos|postal|a[eé]reo))?\s*\d|\bapdo\.?\s*\d|\bcasilla(\s+de\s+correos?)?\s*\d)`),
+	"pt": regexp.MustCompile(`(?i)(\bcaixa\s+postal\b|\bapartado\s*\d)`),
+	"nl": regexp.MustCompile(`(?i)\bpostbus\b`),
+	"sv": regexp.MustCompile(`(?i)\bbox\s+\d`),
+	"no": regexp.MustCompile(`(?i)\bpostboks\b`),
+	"da": regexp.MustCompile(`(?i)\bpostboks\b`),
+	"fi": regexp.MustCompile(`(?i)(\bpostilokero\b|\bpl\s+\d)`),
+	"pl": regexp.MustCompile(`(?i)\bskrytka\s+pocztowa\b`),
+	"ru": regexp.MustCompile(`(?i)(абонентский\s+ящик|а/я\s*\d)`),
+	"ja": regexp.MustCompile(`私書箱`),
+	"zh": regexp.MustCompile(`(邮政信箱|郵政信箱)`),
+}
+
+// poBoxLanguages are the PO box pattern languages, keyed by country code.
+//
+// English patterns are always used, and are not listed.
+var poBoxLanguages = map[string][]string{
+	"AR": {"es"}, "AT": {"de"}, "BE": {"fr", "nl"}, "BO": {"es"}, "BR": {"pt"},
+	"BY": {"ru"}, "CA": {"fr"}, "CH": {"de", "fr", "it"}, "CL": {"es"}, "CN": {"zh"},
+	"CO": {"es"}, "CR": {"es"}, "DE": {"de"}, "DK": {"da"}, "EC": {"es"},
+	"ES": {"es"}, "FI": {"fi", "sv"}, "FR": {"fr"}, "GT": {"es"}, "HK": {"zh"},
+	"IT": {"it"}, "JP": {"ja"}, "KZ": {"ru"}, "LI": {"de"}, "LU": {"fr", "de"},
+	"MC": {"fr"}, "MO": {"zh", "pt"}, "MX": {"es"}, "NL": {"nl"}, "NO": {"no"},
+	"PA": {"es"}, "PE": {"es"}, "PL": {"pl"}, "PT": {"pt"}, "PY": {"es"},
+	"RU": {"ru"}, "SE": {"sv"}, "SM": {"it"}, "TW": {"zh"}, "UY": {"es"},
+	"VA": {"it"}, "VE": {"es"},
+}
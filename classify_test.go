@@ -0,0 +1,83 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestAddress_Classify(t *testing.T) {
+	tests := []struct {
+		addr address.Address
+		want address.AddressType
+	}{
+		// Street addresses.
+		{address.Address{Line1: "1098 Alta Ave", Locality: "Mountain View", Region: "CA", CountryCode: "US"}, address.AddressTypeStreet},
+		{address.Address{Line1: "Boxwood Ln 5", Locality: "Austin", Region: "TX", CountryCode: "US"}, address.AddressTypeStreet},
+		{address.Address{Line1: "Postfachstraße 5", Locality: "Berlin", CountryCode: "DE"}, address.AddressTypeStreet},
+		// The Swedish pattern is not used outside of Sweden and Finland.
+		{address.Address{Line1: "Box 12", Locality: "Berlin", CountryCode: "DE"}, address.AddressTypeStreet},
+
+		// Military addresses.
+		{address.Address{Line1: "Unit 2050 Box 4190", Locality: "APO", Region: "AP", PostalCode: "96278", CountryCode: "US"}, address.AddressTypeMilitary},
+		{address.Address{Line1: "PSC 802 Box 74", Locality: "apo", Region: "AE", PostalCode: "09499", CountryCode: "US"}, address.AddressTypeMilitary},
+		{address.Address{Line1: "USS Enterprise", Locality: "FPO", PostalCode: "34092", CountryCode: "US"}, address.AddressTypeMilitary},
+
+		// PO boxes.
+		{address.Address{Line1: "PO Box 123", Locality: "Austin", Region: "TX", CountryCode: "US"}, address.AddressTypePOBox},
+		{address.Address{Line1: "P.O. Box 123", Locality: "Austin", Region: "TX", CountryCode: "US"}, address.AddressTypePOBox},
+		{address.Address{Line1: "GPO Box 1234", Locality: "Sydney", Region: "NSW", CountryCode: "AU"}, address.AddressTypePOBox},
+		{address.Address{Line1: "Postfach 10 01 01", Locality: "Berlin", CountryCode: "DE"}, address.AddressTypePOBox},
+		{address.Address{Line1: "Boîte postale 52", Locality: "Paris", CountryCode: "FR"}, address.AddressTypePOBox},
+		{address.Address{Line1: "BP 52", Locality: "Paris", CountryCode: "FR"}, address.AddressTypePOBox},
+		{address.Address{Line1: "Caixa Postal 1234", Locality: "São Paulo", Region: "SP", CountryCode: "BR"}, address.AddressTypePOBox},
+		{address.Address{Line1: "Apartado de Correos 45", Locality: "Madrid", CountryCode: "ES"}, address.AddressTypePOBox},
+		{address.Address{Line1: "Postbus 1000", Locality: "Amsterdam", CountryCode: "NL"}, address.AddressTypePOBox},
+		{address.Address{Line1: "Box 12", Locality: "Stockholm", CountryCode: "SE"}, address.AddressTypePOBox},
+		{address.Address{Line1: "а/я 15", Locality: "Москва", CountryCode: "RU"}, address.AddressTypePOBox},
+		{address.Address{Line1: "東京中央郵便局私書箱1号", Region: "13", CountryCode: "JP"}, address.AddressTypePOBox},
+
+		// Parcel lockers.
+		{address.Address{Line1: "12345678", Line2: "Packstation 101", Locality: "Berlin", CountryCode: "DE"}, address.AddressTypeParcelLocker},
+		{address.Address{Line1: "Paczkomat WAW01M", Locality: "Warszawa", CountryCode: "PL"}, address.AddressTypeParcelLocker},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tt.addr.Classify()
+			if got != tt.want {
+				t.Errorf("%v: got %v, want %v", tt.addr.Line1, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddress_ClassifyMilitaryRegions(t *testing.T) {
+	// Confirm that the Armed Forces regions exist in the US format.
+	format := address.GetFormat("US")
+	for _, region := range []string{"AA", "AE", "AP"} {
+		if !format.CheckRegion(region) {
+			t.Errorf("expected %v to be a valid US region.", region)
+		}
+		addr := address.Address{Line1: "PSC 802 Box 74", Region: region, CountryCode: "US"}
+		if got := addr.Classify(); got != address.AddressTypeMilitary {
+			t.Errorf("got %v, want %v", got, address.AddressTypeMilitary)
+		}
+	}
+}
+
+func TestAddressType_MarshalText(t *testing.T) {
+	b, _ := address.AddressTypePOBox.MarshalText()
+	if string(b) != "po_box" {
+		t.Errorf("got %s, want po_box", b)
+	}
+	var addrType address.AddressType
+	if err := addrType.UnmarshalText([]byte("parcel_locker")); err != nil || addrType != address.AddressTypeParcelLocker {
+		t.Errorf("got %v, %v, want %v", addrType, err, address.AddressTypeParcelLocker)
+	}
+	if err := addrType.UnmarshalText([]byte("castle")); err == nil {
+		t.Error("expected an error for an invalid address type.")
+	}
+}
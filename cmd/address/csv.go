@@ -0,0 +1,63 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bojanz/address"
+)
+
+// runCSV reads a CSV file from stdin, and writes the normalized addresses
+// to stdout, using the default column keys. Row errors are written to stderr.
+//
+// Returns 1 if any row is invalid, and 2 if reading or writing fails.
+func runCSV(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("csv", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	columns := flags.String("columns", "", `column mapping, e.g. "Street=line1,City=locality,Country=country"`)
+	defaultCountry := flags.String("default-country", "", "country code used for rows without a country")
+	countryNames := flags.Bool("country-names", false, "write country names instead of country codes")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	columnMap, err := parseColumns(*columns)
+	if err != nil {
+		fmt.Fprintf(stderr, "address csv: %v\n", err)
+		return 2
+	}
+
+	r := address.NewCSVReader(stdin)
+	r.Columns = columnMap
+	r.DefaultCountryCode = strings.ToUpper(*defaultCountry)
+	w := address.NewCSVWriter(stdout)
+	w.CountryNames = *countryNames
+	addrs, report, err := r.ReadAll()
+	if err != nil {
+		fmt.Fprintf(stderr, "address csv: %v\n", err)
+		return 2
+	}
+	for _, addr := range addrs {
+		if err := w.Write(addr); err != nil {
+			fmt.Fprintf(stderr, "address csv: %v\n", err)
+			return 2
+		}
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(stderr, "address csv: %v\n", err)
+		return 2
+	}
+
+	for _, rowErr := range report.Errors {
+		fmt.Fprintln(stderr, rowErr)
+	}
+	fmt.Fprintf(stderr, "%d rows, %d valid, %d invalid\n", report.Rows, report.Valid(), len(report.Errors))
+	if len(report.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
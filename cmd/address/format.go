@@ -0,0 +1,80 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bojanz/address"
+)
+
+// runFormat reads addresses from stdin, and writes the formatted addresses to stdout.
+//
+// HTML and text addresses are separated by a blank line, single-line addresses
+// are written one per line. Addresses that could not be read are skipped,
+// invalid addresses are formatted as-is. Errors are written to stderr.
+func runFormat(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var in inputFlags
+	flags := flag.NewFlagSet("format", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	in.register(flags)
+	output := flags.String("output", "text", `output format, "html", "text" or "single-line"`)
+	locale := flags.String("locale", "en", "locale used for selecting the layout and regions")
+	originCountry := flags.String("origin-country", "", "country code of the sender; the country is only shown for international addresses")
+	transliterate := flags.Bool("transliterate", false, "transliterate addresses to the Latin script")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	formatter := address.NewFormatter(address.NewLocale(*locale))
+	formatter.OriginCountryCode = strings.ToUpper(*originCountry)
+	formatter.Transliterate = *transliterate
+	var format func(addr address.Address) string
+	separator := "\n\n"
+	switch *output {
+	case "html":
+		format = formatter.Format
+	case "text":
+		format = formatter.FormatText
+	case "single-line":
+		format = func(addr address.Address) string {
+			return strings.ReplaceAll(formatter.FormatText(addr), "\n", ", ")
+		}
+		separator = "\n"
+	default:
+		fmt.Fprintf(stderr, "address format: invalid output format %q\n", *output)
+		return 2
+	}
+
+	failed := 0
+	count := 0
+	err := readAddresses(stdin, in, func(rec record) error {
+		if rec.readErr != nil {
+			failed++
+			fmt.Fprintln(stderr, rec)
+			return nil
+		}
+		if count > 0 {
+			if _, err := io.WriteString(stdout, separator); err != nil {
+				return err
+			}
+		}
+		count++
+		_, err := io.WriteString(stdout, format(rec.addr))
+		return err
+	})
+	if err == nil && count > 0 {
+		_, err = io.WriteString(stdout, "\n")
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "address format: %v\n", err)
+		return 2
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
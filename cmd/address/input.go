@@ -0,0 +1,155 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bojanz/address"
+)
+
+// record is an address read from the input.
+type record struct {
+	// line is the line number in the input, starting from 1.
+	line int
+	addr address.Address
+	// readErr is set if the address could not be read (e.g. invalid JSON, unknown country).
+	readErr error
+	// validationErrs are the validation errors of the normalized address.
+	validationErrs []address.ValidationError
+}
+
+// errors returns all record errors.
+func (r record) errors() []error {
+	if r.readErr != nil {
+		return []error{r.readErr}
+	}
+	errs := make([]error, len(r.validationErrs))
+	for i, err := range r.validationErrs {
+		errs[i] = err
+	}
+	return errs
+}
+
+// String returns the record errors, prefixed by the line number.
+func (r record) String() string {
+	errs := r.errors()
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("line %d: %v", r.line, strings.Join(messages, "; "))
+}
+
+// inputFlags are the flags shared by commands that read addresses.
+type inputFlags struct {
+	input          string
+	columns        string
+	defaultCountry string
+}
+
+// register registers the input flags on the given flag set.
+func (f *inputFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.input, "input", "jsonl", `input format, "jsonl" or "csv"`)
+	flags.StringVar(&f.columns, "columns", "", `CSV column mapping, e.g. "Street=line1,City=locality,Country=country"`)
+	flags.StringVar(&f.defaultCountry, "default-country", "", "country code used for addresses without a country")
+}
+
+// readAddresses reads, normalizes and validates addresses from r,
+// calling fn for each one.
+//
+// JSON lines contain one address per line, in the Address JSON form.
+// CSV files must have a header, see address.CSVReader. Both are prepared
+// by address.Import, so the country can be a code or an English name.
+func readAddresses(r io.Reader, f inputFlags, fn func(rec record) error) error {
+	defaultCountryCode := strings.ToUpper(f.defaultCountry)
+	switch f.input {
+	case "jsonl":
+		return readJSONLines(r, defaultCountryCode, fn)
+	case "csv":
+		columns, err := parseColumns(f.columns)
+		if err != nil {
+			return err
+		}
+		return readCSV(r, columns, defaultCountryCode, fn)
+	}
+	return fmt.Errorf("invalid input format %q", f.input)
+}
+
+// readJSONLines reads addresses from JSON lines. Blank lines are skipped.
+func readJSONLines(r io.Reader, defaultCountryCode string, fn func(rec record) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := strings.TrimSpace(scanner.Text())
+		if data == "" {
+			continue
+		}
+		rec := record{line: line}
+		if err := json.Unmarshal([]byte(data), &rec.addr); err != nil {
+			rec.readErr = fmt.Errorf("invalid JSON: %w", err)
+		} else {
+			rec.addr, rec.validationErrs, rec.readErr = address.Import(rec.addr, defaultCountryCode)
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// readCSV reads addresses from a CSV file.
+func readCSV(r io.Reader, columns map[string]string, defaultCountryCode string, fn func(rec record) error) error {
+	cr := address.NewCSVReader(r)
+	cr.Columns = columns
+	cr.DefaultCountryCode = defaultCountryCode
+	for {
+		addr, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		rec := record{line: cr.Line(), addr: addr}
+		if err != nil {
+			var rowErr *address.CSVRowError
+			if !errors.As(err, &rowErr) {
+				return err
+			}
+			for _, err := range rowErr.Errors {
+				var validationErr address.ValidationError
+				if !errors.As(err, &validationErr) {
+					rec.readErr = err
+					break
+				}
+				rec.validationErrs = append(rec.validationErrs, validationErr)
+			}
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+}
+
+// parseColumns parses a column mapping in the "Header=key,Header=key" form.
+func parseColumns(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	columns := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		name, key, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid column mapping %q", pair)
+		}
+		columns[strings.TrimSpace(name)] = strings.TrimSpace(key)
+	}
+	return columns, nil
+}
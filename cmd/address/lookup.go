@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bojanz/address"
+)
+
+// runLookup writes the address format of the given country to stdout, as JSON.
+//
+// With -regions, the regions are written instead, one "ID<tab>name" pair per line.
+func runLookup(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("lookup", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: address lookup [flags] <country code>")
+		flags.PrintDefaults()
+	}
+	locale := flags.String("locale", "en", "locale used for selecting the layout and regions")
+	regions := flags.Bool("regions", false, "list the regions instead of the address format")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	countryCode := strings.ToUpper(flags.Arg(0))
+	if countryCode == "" || !address.CheckCountryCode(countryCode) {
+		fmt.Fprintf(stderr, "address lookup: unknown country code %q\n", flags.Arg(0))
+		return 2
+	}
+	format := address.GetFormat(countryCode)
+	l := address.NewLocale(*locale)
+
+	if *regions {
+		regionMap := format.SelectRegions(l)
+		for _, key := range regionMap.Keys() {
+			name, _ := regionMap.Get(key)
+			fmt.Fprintf(stdout, "%v\t%v\n", key, name)
+		}
+		return 0
+	}
+	data := struct {
+		CountryCode string `json:"country"`
+		CountryName string `json:"country_name"`
+		Layout      string `json:"layout"`
+		address.Format
+	}{
+		CountryCode: countryCode,
+		CountryName: address.GetCountryNames()[countryCode],
+		Layout:      format.SelectLayout(l),
+		Format:      format,
+	}
+	// The selected layout replaces both layouts.
+	data.Format.LocalLayout = ""
+	enc := json.NewEncoder(stdout)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		fmt.Fprintf(stderr, "address lookup: %v\n", err)
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+// Command address processes addresses using the address package.
+//
+// Usage:
+//
+//	address <command> [flags]
+//
+// Commands:
+//
+//	validate   validate addresses
+//	normalize  normalize addresses
+//	format     format addresses as HTML, text or a single line
+//	lookup     show the address format or regions of a country
+//	csv        normalize and validate a CSV file of addresses
+//
+// Addresses are read from stdin, either as JSON lines (the default) or CSV:
+//
+//	echo '{"line1": "1098 Alta Ave", "locality": "Mountain View", "region": "CA", "postal_code": "94043", "country": "US"}' | address format
+//	address validate -input csv -columns "Street=line1,City=locality,Country=country" < addresses.csv
+//
+// Run "address <command> -h" for the flags of each command.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// commands are the available commands, keyed by name.
+var commands = map[string]func(args []string, stdin io.Reader, stdout, stderr io.Writer) int{
+	"validate":  runValidate,
+	"normalize": runNormalize,
+	"format":    runFormat,
+	"lookup":    runLookup,
+	"csv":       runCSV,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command named by the first argument, returning the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "address: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	return command(args[1:], stdin, stdout, stderr)
+}
+
+// usage prints the list of commands.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: address <command> [flags]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+	fmt.Fprintln(w, "  validate   validate addresses")
+	fmt.Fprintln(w, "  normalize  normalize addresses")
+	fmt.Fprintln(w, "  format     format addresses as HTML, text or a single line")
+	fmt.Fprintln(w, "  lookup     show the address format or regions of a country")
+	fmt.Fprintln(w, "  csv        normalize and validate a CSV file of addresses")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, `Run "address <command> -h" for the flags of each command.`)
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun_Unknown(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("got exit code %v, want 2", code)
+	}
+	if code := run([]string{"foo"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("got exit code %v, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), `unknown command "foo"`) {
+		t.Errorf("got %q, want an unknown command error", stderr.String())
+	}
+}
+
+func TestRunCSV(t *testing.T) {
+	input := strings.Join([]string{
+		"Street,City,State,ZIP,Country",
+		"1098 Alta Ave,Mountain View,california,94043,United States",
+		"10 Downing St,London,,sw1a2aa,",
+		"Somewhere,Atlantis,,,Atlantis",
+	}, "\n")
+	var stdout, stderr bytes.Buffer
+	args := []string{"-columns", "Street=line1,City=locality,State=region,ZIP=postal_code,Country=country", "-default-country", "gb"}
+	code := run(append([]string{"csv"}, args...), strings.NewReader(input), &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("got exit code %v, want 1", code)
+	}
+	wantStdout := strings.Join([]string{
+		"line1,line2,line3,sublocality,locality,region,postal_code,country",
+		"1098 Alta Ave,,,,Mountain View,CA,94043,US",
+		"10 Downing St,,,,London,,SW1A 2AA,GB",
+		"",
+	}, "\n")
+	if stdout.String() != wantStdout {
+		t.Errorf("got stdout:\n%v\nwant:\n%v", stdout.String(), wantStdout)
+	}
+	wantStderr := "line 4: unknown country \"Atlantis\"\n3 rows, 2 valid, 1 invalid\n"
+	if stderr.String() != wantStderr {
+		t.Errorf("got stderr %q, want %q", stderr.String(), wantStderr)
+	}
+
+	// Invalid column mapping.
+	stderr.Reset()
+	code = run([]string{"csv", "-columns", "Street"}, strings.NewReader(input), &stdout, &stderr)
+	if code != 2 {
+		t.Errorf("got exit code %v, want 2", code)
+	}
+
+	// Missing header.
+	stderr.Reset()
+	code = run([]string{"csv"}, strings.NewReader(""), &stdout, &stderr)
+	if code != 2 {
+		t.Errorf("got exit code %v, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), "missing CSV header") {
+		t.Errorf("got stderr %q, want a missing header error", stderr.String())
+	}
+}
+
+const testJSONLines = `{"line1": "1098 Alta Ave", "locality": "Mountain View", "region": "california", "postal_code": "94043", "country": "us"}
+
+{"line1": "Somewhere", "country": "Atlantis"}
+{"line1": "Unit 2050", "locality": "Mountain View", "country": "US"}
+{"line1": "10 Downing St", "locality": "London", "postal_code": "sw1a2aa"}
+`
+
+func TestRunValidate(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"validate", "-default-country", "GB"}, strings.NewReader(testJSONLines), &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("got exit code %v, want 1", code)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("got stdout %q, want an empty string", stdout.String())
+	}
+	wantStderr := strings.Join([]string{
+		`line 3: unknown country "Atlantis"`,
+		`line 4: field "region" is required; field "postal_code" is required`,
+		"4 addresses, 2 valid, 2 invalid",
+		"",
+	}, "\n")
+	if stderr.String() != wantStderr {
+		t.Errorf("got stderr:\n%v\nwant:\n%v", stderr.String(), wantStderr)
+	}
+
+	// CSV input.
+	stdout.Reset()
+	stderr.Reset()
+	input := "Street,City,State,ZIP\n1098 Alta Ave,Mountain View,CA,94043\n"
+	args := []string{"validate", "-input", "csv", "-columns", "Street=line1,City=locality,State=region,ZIP=postal_code", "-default-country", "US"}
+	if code := run(args, strings.NewReader(input), &stdout, &stderr); code != 0 {
+		t.Errorf("got exit code %v, want 0: %v", code, stderr.String())
+	}
+
+	// Invalid input format.
+	if code := run([]string{"validate", "-input", "xml"}, strings.NewReader(""), &stdout, &stderr); code != 2 {
+		t.Errorf("got exit code %v, want 2", code)
+	}
+}
+
+func TestRunNormalize(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"normalize", "-default-country", "GB", "-output", "csv"}, strings.NewReader(testJSONLines), &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("got exit code %v, want 1", code)
+	}
+	wantStdout := strings.Join([]string{
+		"line1,line2,line3,sublocality,locality,region,postal_code,country",
+		"1098 Alta Ave,,,,Mountain View,CA,94043,US",
+		"Unit 2050,,,,Mountain View,,,US",
+		"10 Downing St,,,,London,,SW1A 2AA,GB",
+		"",
+	}, "\n")
+	if stdout.String() != wantStdout {
+		t.Errorf("got stdout:\n%v\nwant:\n%v", stdout.String(), wantStdout)
+	}
+
+	stdout.Reset()
+	input := `{"line1": " 1098  Alta Ave ", "region": "California", "country": "US"}`
+	run([]string{"normalize"}, strings.NewReader(input), &stdout, &stderr)
+	want := `{"line1":"1098 Alta Ave","line2":"","line3":"","sublocality":"","locality":"","region":"CA","postal_code":"","country":"US"}` + "\n"
+	if stdout.String() != want {
+		t.Errorf("got %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestRunFormat(t *testing.T) {
+	input := `{"line1": "1098 Alta Ave", "locality": "Mountain View", "region": "CA", "postal_code": "94043", "country": "US"}
+{"line1": "10 Downing St", "locality": "London", "postal_code": "SW1A 2AA", "country": "GB"}
+`
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{
+			[]string{"format"},
+			"1098 Alta Ave\nMountain View, CA 94043\nUnited States\n\n10 Downing St\nLondon\nSW1A 2AA\nUnited Kingdom\n",
+		},
+		{
+			[]string{"format", "-output", "single-line", "-origin-country", "us"},
+			"1098 Alta Ave, Mountain View, CA 94043\n10 Downing St, London, SW1A 2AA, UNITED KINGDOM\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(input), &stdout, &stderr); code != 0 {
+				t.Errorf("got exit code %v, want 0: %v", code, stderr.String())
+			}
+			if stdout.String() != tt.want {
+				t.Errorf("got %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+
+	var stdout, stderr bytes.Buffer
+	run([]string{"format", "-output", "html"}, strings.NewReader(input), &stdout, &stderr)
+	if got := strings.Count(stdout.String(), `<p class="address"`); got != 2 {
+		t.Errorf("got %v addresses, want 2", got)
+	}
+}
+
+func TestRunLookup(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"lookup", "-regions", "us"}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Errorf("got exit code %v, want 0: %v", code, stderr.String())
+	}
+	if !strings.HasPrefix(stdout.String(), "AL\tAlabama\nAK\tAlaska\n") {
+		t.Errorf("got %q, want US regions", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"lookup", "CH"}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Errorf("got exit code %v, want 0: %v", code, stderr.String())
+	}
+	for _, want := range []string{`"country": "CH"`, `"country_name": "Switzerland"`, `"postal_code_pattern": "\\d{4}"`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("got %v, want it to contain %v", stdout.String(), want)
+		}
+	}
+
+	for _, args := range [][]string{{"lookup"}, {"lookup", "XX"}} {
+		if code := run(args, strings.NewReader(""), &stdout, &stderr); code != 2 {
+			t.Errorf("%v: got exit code %v, want 2", args, code)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/bojanz/address"
+)
+
+// runNormalize reads addresses from stdin, and writes the normalized
+// addresses to stdout. Errors are written to stderr.
+//
+// Addresses that could not be read are skipped. Returns 1 if any address is invalid.
+func runNormalize(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var in inputFlags
+	flags := flag.NewFlagSet("normalize", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	in.register(flags)
+	output := flags.String("output", "", `output format, "jsonl" or "csv" (default: the input format)`)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *output == "" {
+		*output = in.input
+	}
+	var write func(addr address.Address) error
+	var flush func() error
+	switch *output {
+	case "jsonl":
+		enc := json.NewEncoder(stdout)
+		enc.SetEscapeHTML(false)
+		write = func(addr address.Address) error { return enc.Encode(addr) }
+		flush = func() error { return nil }
+	case "csv":
+		w := address.NewCSVWriter(stdout)
+		write = w.Write
+		flush = w.Flush
+	default:
+		fmt.Fprintf(stderr, "address normalize: invalid output format %q\n", *output)
+		return 2
+	}
+
+	invalid := 0
+	err := readAddresses(stdin, in, func(rec record) error {
+		if len(rec.errors()) > 0 {
+			invalid++
+			fmt.Fprintln(stderr, rec)
+		}
+		if rec.readErr != nil {
+			return nil
+		}
+		return write(rec.addr)
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "address normalize: %v\n", err)
+		return 2
+	}
+	if invalid > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+// runValidate reads addresses from stdin, and writes the errors of
+// invalid addresses to stderr, one line per address.
+//
+// Addresses are normalized before being validated. Returns 1 if any address is invalid.
+func runValidate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var in inputFlags
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	in.register(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	total, invalid := 0, 0
+	err := readAddresses(stdin, in, func(rec record) error {
+		total++
+		if len(rec.errors()) > 0 {
+			invalid++
+			fmt.Fprintln(stderr, rec)
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "address validate: %v\n", err)
+		return 2
+	}
+	fmt.Fprintf(stderr, "%d addresses, %d valid, %d invalid\n", total, total-invalid, invalid)
+	if invalid > 0 {
+		return 1
+	}
+	return 0
+}
@@ -18,6 +18,38 @@ const (
 	FieldPostalCode  Field = "P"
 )
 
+// Autocomplete returns the HTML autocomplete token for f.
+//
+// See https://html.spec.whatwg.org/multipage/form-control-infrastructure.html#autofill
+func (f Field) Autocomplete() string {
+	switch f {
+	case FieldLine1:
+		return "address-line1"
+	case FieldLine2:
+		return "address-line2"
+	case FieldLine3:
+		return "address-line3"
+	case FieldSublocality:
+		return "address-level3"
+	case FieldLocality:
+		return "address-level2"
+	case FieldRegion:
+		return "address-level1"
+	case FieldPostalCode:
+		return "postal-code"
+	}
+	return ""
+}
+
+// isKnownField returns whether the given field is known.
+func isKnownField(field Field) bool {
+	switch field {
+	case FieldLine1, FieldLine2, FieldLine3, FieldSublocality, FieldLocality, FieldRegion, FieldPostalCode:
+		return true
+	}
+	return false
+}
+
 // SublocalityType represents the sublocality type.
 type SublocalityType uint8
 
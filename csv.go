@@ -0,0 +1,286 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// csvColumns are the CSV column keys, matching the JSON representation of Address.
+var csvColumns = []string{"line1", "line2", "line3", "sublocality", "locality", "region", "postal_code", "country"}
+
+// CSVRowError represents an invalid CSV row.
+type CSVRowError struct {
+	// Line is the line number in the CSV file, starting from 1.
+	Line int
+	// Errors are the row errors (e.g. an unknown country, ValidationError).
+	Errors []error
+}
+
+// Error implements the error interface.
+func (e *CSVRowError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, strings.Join(messages, "; "))
+}
+
+// CSVReport summarizes the rows read by a CSVReader.
+type CSVReport struct {
+	// Rows is the number of rows, excluding the header.
+	Rows int
+	// Errors are the errors of invalid rows.
+	Errors []*CSVRowError
+}
+
+// Valid returns the number of valid rows.
+func (r CSVReport) Valid() int {
+	return r.Rows - len(r.Errors)
+}
+
+// CSVReader reads addresses from a CSV file.
+//
+// The first row must be a header. Each row is normalized and validated.
+type CSVReader struct {
+	r      *csv.Reader
+	keys   []string
+	line   int
+	report CSVReport
+	// Columns maps header names to column keys ("line1", "line2", "line3",
+	// "sublocality", "locality", "region", "postal_code", "country").
+	// Header names are matched case-insensitively. Unmapped columns are ignored.
+	// Defaults to nil, in which case header names must match column keys.
+	Columns map[string]string
+	// DefaultCountryCode is used for rows without a country.
+	// Defaults to an empty string.
+	DefaultCountryCode string
+	// Registry provides the address formats.
+	// Defaults to nil, in which case the built-in formats are used.
+	Registry *Registry
+}
+
+// NewCSVReader creates a new CSV reader for the given io.Reader.
+func NewCSVReader(r io.Reader) *CSVReader {
+	cr := &CSVReader{
+		r: csv.NewReader(r),
+	}
+	cr.r.FieldsPerRecord = -1
+	cr.r.TrimLeadingSpace = true
+	return cr
+}
+
+// Read reads and normalizes the next address.
+//
+// The country column can contain either a country code or an English
+// country name. Returns a *CSVRowError if the row is invalid, in which
+// case reading can continue. The address is empty if the row could not be
+// parsed or has an unknown country. Returns io.EOF when there are no more rows.
+func (cr *CSVReader) Read() (Address, error) {
+	if cr.keys == nil {
+		if err := cr.readHeader(); err != nil {
+			return Address{}, err
+		}
+	}
+	record, err := cr.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			cr.line = parseErr.Line
+			cr.report.Rows++
+			return Address{}, cr.rowError([]error{parseErr.Err})
+		}
+		return Address{}, err
+	}
+	cr.line, _ = cr.r.FieldPos(0)
+	cr.report.Rows++
+
+	addr := Address{}
+	for i, value := range record {
+		if i >= len(cr.keys) {
+			break
+		}
+		switch cr.keys[i] {
+		case "line1":
+			addr.Line1 = value
+		case "line2":
+			addr.Line2 = value
+		case "line3":
+			addr.Line3 = value
+		case "sublocality":
+			addr.Sublocality = value
+		case "locality":
+			addr.Locality = value
+		case "region":
+			addr.Region = value
+		case "postal_code":
+			addr.PostalCode = value
+		case "country":
+			addr.CountryCode = value
+		}
+	}
+	addr, validationErrs, err := importAddress(cr.Registry, addr, cr.DefaultCountryCode)
+	if err != nil {
+		return Address{}, cr.rowError([]error{err})
+	}
+	if len(validationErrs) > 0 {
+		errs := make([]error, len(validationErrs))
+		for i, validationErr := range validationErrs {
+			errs[i] = validationErr
+		}
+		return addr, cr.rowError(errs)
+	}
+
+	return addr, nil
+}
+
+// ReadAll reads and normalizes all remaining addresses.
+//
+// Invalid rows are included in the returned addresses, and listed in the report.
+// Rows that could not be read into an address (e.g. because of an unknown
+// country) are skipped, and only listed in the report.
+// An error is returned only if reading fails.
+func (cr *CSVReader) ReadAll() ([]Address, CSVReport, error) {
+	var addrs []Address
+	for {
+		addr, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		var rowErr *CSVRowError
+		if err != nil && !errors.As(err, &rowErr) {
+			return addrs, cr.report, err
+		}
+		if addr.IsEmpty() {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+
+	return addrs, cr.report, nil
+}
+
+// Line returns the line number of the last row read, starting from 1.
+func (cr *CSVReader) Line() int {
+	return cr.line
+}
+
+// Report returns the report of the rows read so far.
+func (cr *CSVReader) Report() CSVReport {
+	return cr.report
+}
+
+// readHeader reads the header and maps it to column keys.
+func (cr *CSVReader) readHeader() error {
+	header, err := cr.r.Read()
+	if err != nil {
+		if err == io.EOF {
+			return fmt.Errorf("missing CSV header")
+		}
+		return err
+	}
+	columns := make(map[string]string, len(cr.Columns))
+	for name, key := range cr.Columns {
+		if !isCSVColumn(key) {
+			return fmt.Errorf("invalid CSV column %q for header %q", key, name)
+		}
+		columns[strings.ToLower(name)] = key
+	}
+	cr.keys = make([]string, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if key, ok := columns[name]; ok {
+			cr.keys[i] = key
+		} else if len(cr.Columns) == 0 && isCSVColumn(name) {
+			cr.keys[i] = name
+		}
+	}
+
+	return nil
+}
+
+// rowError records and returns an error for the current row.
+func (cr *CSVReader) rowError(errs []error) *CSVRowError {
+	rowErr := &CSVRowError{Line: cr.line, Errors: errs}
+	cr.report.Errors = append(cr.report.Errors, rowErr)
+	return rowErr
+}
+
+// CSVWriter writes addresses to a CSV file.
+type CSVWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+	// Header lists the header names, in column order.
+	// Defaults to the column keys: "line1", "line2", "line3",
+	// "sublocality", "locality", "region", "postal_code", "country".
+	Header []string
+	// Columns maps header names to column keys.
+	// Defaults to nil, in which case header names must match column keys.
+	Columns map[string]string
+	// CountryNames writes English country names instead of country codes.
+	// Defaults to false.
+	CountryNames bool
+}
+
+// NewCSVWriter creates a new CSV writer for the given io.Writer.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{
+		w:      csv.NewWriter(w),
+		Header: append([]string(nil), csvColumns...),
+	}
+}
+
+// Write writes the given address, preceded by the header if not yet written.
+func (cw *CSVWriter) Write(addr Address) error {
+	if !cw.headerWritten {
+		if err := cw.w.Write(cw.Header); err != nil {
+			return err
+		}
+		cw.headerWritten = true
+	}
+	record := make([]string, len(cw.Header))
+	for i, name := range cw.Header {
+		key := name
+		if cw.Columns != nil {
+			key = cw.Columns[name]
+		}
+		switch key {
+		case "line1":
+			record[i] = addr.Line1
+		case "line2":
+			record[i] = addr.Line2
+		case "line3":
+			record[i] = addr.Line3
+		case "sublocality":
+			record[i] = addr.Sublocality
+		case "locality":
+			record[i] = addr.Locality
+		case "region":
+			record[i] = addr.Region
+		case "postal_code":
+			record[i] = addr.PostalCode
+		case "country":
+			record[i] = addr.CountryCode
+			if cw.CountryNames && countries[addr.CountryCode] != "" {
+				record[i] = countries[addr.CountryCode]
+			}
+		}
+	}
+	return cw.w.Write(record)
+}
+
+// Flush writes any buffered data, returning any error that occurred.
+func (cw *CSVWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// isCSVColumn returns whether the given column key is known.
+func isCSVColumn(key string) bool {
+	return contains(csvColumns, key)
+}
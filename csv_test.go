@@ -0,0 +1,154 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestCSVReader_ReadAll(t *testing.T) {
+	data := strings.Join([]string{
+		"Street,Suite,City,State,ZIP,Country,Notes",
+		"1098 Alta Ave,,Mountain View,California,94043,United States,HQ",
+		"1 Calle Fortaleza,,San Juan,,00901,PR,",
+		"Unit 2050,,,,9404,United States,",
+		"Somewhere,,Atlantis,,,Atlantis,",
+		"10 Downing St,,London,,sw1a2aa,,",
+	}, "\n")
+	r := address.NewCSVReader(strings.NewReader(data))
+	r.Columns = map[string]string{
+		"street":  "line1",
+		"Suite":   "line2",
+		"City":    "locality",
+		"State":   "region",
+		"ZIP":     "postal_code",
+		"Country": "country",
+	}
+	r.DefaultCountryCode = "GB"
+	addrs, report, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantAddrs := []address.Address{
+		{Line1: "1098 Alta Ave", Locality: "Mountain View", Region: "CA", PostalCode: "94043", CountryCode: "US"},
+		{Line1: "1 Calle Fortaleza", Locality: "San Juan", PostalCode: "00901", CountryCode: "PR"},
+		{Line1: "Unit 2050", PostalCode: "9404", CountryCode: "US"},
+		// The "Atlantis" row is skipped.
+		{Line1: "10 Downing St", Locality: "London", PostalCode: "SW1A 2AA", CountryCode: "GB"},
+	}
+	if !reflect.DeepEqual(addrs, wantAddrs) {
+		t.Errorf("got %#v, want %#v", addrs, wantAddrs)
+	}
+	if report.Rows != 5 {
+		t.Errorf("got %v rows, want 5", report.Rows)
+	}
+	if report.Valid() != 3 {
+		t.Errorf("got %v valid rows, want 3", report.Valid())
+	}
+	wantErrors := []string{
+		`line 4: field "locality" is required; field "region" is required; field "postal_code" is invalid`,
+		`line 5: unknown country "Atlantis"`,
+	}
+	if len(report.Errors) != len(wantErrors) {
+		t.Fatalf("got %v errors, want %v", len(report.Errors), len(wantErrors))
+	}
+	for i, rowErr := range report.Errors {
+		if rowErr.Error() != wantErrors[i] {
+			t.Errorf("got %q, want %q", rowErr.Error(), wantErrors[i])
+		}
+	}
+	// Validation errors are available for inspection.
+	var validationErr address.ValidationError
+	if !errors.As(report.Errors[0].Errors[0], &validationErr) || validationErr.Field != address.FieldLocality {
+		t.Errorf("got %v, want a locality validation error", report.Errors[0].Errors[0])
+	}
+}
+
+func TestCSVReader_DefaultColumns(t *testing.T) {
+	data := "\ufeffline1,locality,region,postal_code,country\n1098 Alta Ave,Mountain View,CA,94043,US\n"
+	r := address.NewCSVReader(strings.NewReader(data))
+	addr, err := r.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := address.Address{Line1: "1098 Alta Ave", Locality: "Mountain View", Region: "CA", PostalCode: "94043", CountryCode: "US"}
+	if addr != want {
+		t.Errorf("got %#v, want %#v", addr, want)
+	}
+}
+
+func TestCSVReader_Invalid(t *testing.T) {
+	// Missing header.
+	r := address.NewCSVReader(strings.NewReader(""))
+	if _, _, err := r.ReadAll(); err == nil {
+		t.Error("expected an error for a missing header.")
+	}
+
+	// Invalid column key.
+	r = address.NewCSVReader(strings.NewReader("Street\n1098 Alta Ave\n"))
+	r.Columns = map[string]string{"Street": "street"}
+	if _, _, err := r.ReadAll(); err == nil {
+		t.Error("expected an error for an invalid column key.")
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	addrs := []address.Address{
+		{Line1: "1098 Alta Ave", Locality: "Mountain View", Region: "CA", PostalCode: "94043", CountryCode: "US"},
+		{Line1: "10 Downing St, Westminster", Locality: "London", PostalCode: "SW1A 2AA", CountryCode: "GB"},
+	}
+	var buf bytes.Buffer
+	w := address.NewCSVWriter(&buf)
+	w.Header = []string{"Street", "City", "State", "ZIP", "Country"}
+	w.Columns = map[string]string{
+		"Street":  "line1",
+		"City":    "locality",
+		"State":   "region",
+		"ZIP":     "postal_code",
+		"Country": "country",
+	}
+	w.CountryNames = true
+	for _, addr := range addrs {
+		if err := w.Write(addr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"Street,City,State,ZIP,Country",
+		"1098 Alta Ave,Mountain View,CA,94043,United States",
+		`"10 Downing St, Westminster",London,,SW1A 2AA,United Kingdom`,
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("got:\n%v\nwant:\n%v", buf.String(), want)
+	}
+
+	// Round trip, using the default columns.
+	buf.Reset()
+	w = address.NewCSVWriter(&buf)
+	for _, addr := range addrs {
+		w.Write(addr)
+	}
+	w.Flush()
+	got, report, err := address.NewCSVReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Errors) != 0 {
+		t.Errorf("got %v, want no errors", report.Errors)
+	}
+	if !reflect.DeepEqual(got, addrs) {
+		t.Errorf("got %#v, want %#v", got, addrs)
+	}
+}
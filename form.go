@@ -0,0 +1,307 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"html"
+	"html/template"
+	"strconv"
+	"strings"
+)
+
+// FormField describes a single field of an address form.
+type FormField struct {
+	Field Field `json:"field"`
+	// LabelType is the type of label to show (e.g. "city", "state", "zip").
+	// Line fields use "line1", "line2", "line3".
+	LabelType string `json:"label_type"`
+	Required  bool   `json:"required,omitempty"`
+	// MaxLength is the maximum number of characters, or 0 if unlimited.
+	MaxLength int `json:"max_length,omitempty"`
+	// Pattern is the regex pattern for validating the value, if any.
+	Pattern string `json:"pattern,omitempty"`
+	// Autocomplete is the HTML autocomplete token (e.g. "address-line1").
+	Autocomplete string `json:"autocomplete"`
+	// Default is the default value, if any.
+	Default string `json:"default,omitempty"`
+	// Options are the allowed values, used for rendering a dropdown.
+	Options []FormFieldOption `json:"options,omitempty"`
+	// Row is the index of the form row, matching the layout line.
+	Row int `json:"row"`
+}
+
+// FormFieldOption represents a dropdown option.
+type FormFieldOption struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// FormFields returns the form fields for the given locale.
+//
+// Fields are returned in layout order, grouped into rows by layout line.
+// Empty layout lines are skipped, and do not increase the row index.
+func (f Format) FormFields(locale Locale) []FormField {
+	layout := f.SelectParsedLayout(locale)
+	regions := f.SelectRegions(locale)
+	formFields := make([]FormField, 0, 7)
+	row := 0
+	for _, line := range layout.Lines {
+		if len(line.Fields) == 0 {
+			continue
+		}
+		for _, field := range line.Fields {
+			ff := FormField{
+				Field:        field,
+				LabelType:    f.labelType(field),
+				Required:     f.IsRequired(field),
+				MaxLength:    f.MaxLength(field),
+				Autocomplete: field.Autocomplete(),
+				Default:      f.Defaults[field],
+				Row:          row,
+			}
+			switch field {
+			case FieldRegion:
+				if regions.Len() > 0 {
+					ff.Options = make([]FormFieldOption, 0, regions.Len())
+					for _, key := range regions.Keys() {
+						label, _ := regions.Get(key)
+						ff.Options = append(ff.Options, FormFieldOption{Value: key, Label: label})
+					}
+				}
+			case FieldPostalCode:
+				ff.Pattern = f.PostalCodePattern
+			}
+			formFields = append(formFields, ff)
+		}
+		row++
+	}
+
+	return formFields
+}
+
+// labelType returns the label type for the given field.
+func (f Format) labelType(field Field) string {
+	switch field {
+	case FieldLine1:
+		return "line1"
+	case FieldLine2:
+		return "line2"
+	case FieldLine3:
+		return "line3"
+	case FieldSublocality:
+		return f.SublocalityType.String()
+	case FieldLocality:
+		return f.LocalityType.String()
+	case FieldRegion:
+		return f.RegionType.String()
+	case FieldPostalCode:
+		return f.PostalCodeType.String()
+	}
+	return ""
+}
+
+// FormRenderer renders address forms as HTML.
+type FormRenderer struct {
+	locale Locale
+	// LabelMapper maps label types to labels.
+	// Can be used to retrieve labels from another (localized) source.
+	// Defaults to a function that uses English labels included in the package.
+	LabelMapper func(labelType string, locale Locale) string
+	// Registry provides the address formats.
+	// Defaults to nil, in which case the built-in formats are used.
+	Registry *Registry
+	// NamePrefix is the prefix for input names.
+	// For example, "shipping_" results in "shipping_line1".
+	// Defaults to an empty string.
+	NamePrefix string
+	// IDPrefix is the prefix for element IDs.
+	// Defaults to "address-".
+	IDPrefix string
+	// AutocompleteSection is the autocomplete section or address type.
+	// For example, "shipping" results in autocomplete="shipping address-line1".
+	// Defaults to an empty string.
+	AutocompleteSection string
+	// WrapperClass is the wrapper HTML class.
+	// Defaults to "address-form".
+	WrapperClass string
+}
+
+// NewFormRenderer creates a new form renderer for the given locale.
+func NewFormRenderer(locale Locale) *FormRenderer {
+	r := &FormRenderer{
+		locale: locale,
+		LabelMapper: func(labelType string, locale Locale) string {
+			return labels[labelType]
+		},
+		IDPrefix:     "address-",
+		WrapperClass: "address-form",
+	}
+	return r
+}
+
+// Locale returns the locale.
+func (r *FormRenderer) Locale() Locale {
+	return r.locale
+}
+
+// Render renders an address form for the given address.
+//
+// The address country code determines the form fields and their layout.
+// Errors are optional validation error messages, keyed by field.
+//
+// All values are escaped, making the output safe to use in html/template.
+func (r *FormRenderer) Render(addr Address, errs map[Field]string) template.HTML {
+	if addr.IsEmpty() {
+		return ""
+	}
+	var format Format
+	if r.Registry != nil {
+		format = r.Registry.Get(addr.CountryCode)
+	} else {
+		format = GetFormat(addr.CountryCode)
+	}
+	formFields := format.FormFields(r.locale)
+
+	sb := strings.Builder{}
+	sb.Grow(2000)
+	sb.WriteString(`<div class="` + html.EscapeString(r.WrapperClass) + `">` + "\n")
+	sb.WriteString(`<input type="hidden" name="` + html.EscapeString(r.NamePrefix+"country") + `" value="`)
+	sb.WriteString(html.EscapeString(addr.CountryCode))
+	sb.WriteString(`">` + "\n")
+	for i, ff := range formFields {
+		if i == 0 || ff.Row != formFields[i-1].Row {
+			sb.WriteString(`<div class="` + html.EscapeString(r.WrapperClass) + `-row">` + "\n")
+		}
+		r.writeField(&sb, ff, addr.getField(ff.Field), errs[ff.Field])
+		if i+1 == len(formFields) || ff.Row != formFields[i+1].Row {
+			sb.WriteString("</div>\n")
+		}
+	}
+	sb.WriteString("</div>")
+
+	return template.HTML(sb.String())
+}
+
+// writeField writes a single form field.
+func (r *FormRenderer) writeField(sb *strings.Builder, ff FormField, value string, errorMessage string) {
+	name := fieldName(ff.Field)
+	class := strings.ReplaceAll(name, "_", "-")
+	id := html.EscapeString(r.IDPrefix + class)
+	autocomplete := ff.Autocomplete
+	if r.AutocompleteSection != "" {
+		autocomplete = r.AutocompleteSection + " " + autocomplete
+	}
+
+	sb.WriteString(`<div class="` + html.EscapeString(r.WrapperClass) + `-field ` + class)
+	if errorMessage != "" {
+		sb.WriteString(" has-error")
+	}
+	sb.WriteString(`">` + "\n")
+	sb.WriteString(`<label for="` + id + `">`)
+	sb.WriteString(html.EscapeString(r.LabelMapper(ff.LabelType, r.locale)))
+	if ff.Required {
+		sb.WriteString(`<span class="required" aria-hidden="true">*</span>`)
+	}
+	sb.WriteString("</label>\n")
+
+	if len(ff.Options) > 0 {
+		sb.WriteString(`<select id="` + id + `"`)
+		sb.WriteString(` name="` + html.EscapeString(r.NamePrefix+name) + `"`)
+	} else {
+		sb.WriteString(`<input type="text" id="` + id + `"`)
+		sb.WriteString(` name="` + html.EscapeString(r.NamePrefix+name) + `"`)
+		sb.WriteString(` value="` + html.EscapeString(value) + `"`)
+	}
+	sb.WriteString(` autocomplete="` + html.EscapeString(autocomplete) + `"`)
+	if ff.Required {
+		sb.WriteString(" required")
+	}
+	if ff.MaxLength > 0 {
+		sb.WriteString(` maxlength="` + strconv.Itoa(ff.MaxLength) + `"`)
+	}
+	if ff.Pattern != "" {
+		sb.WriteString(` pattern="` + html.EscapeString(ff.Pattern) + `"`)
+	}
+	if errorMessage != "" {
+		sb.WriteString(` aria-invalid="true" aria-describedby="` + id + `-error"`)
+	}
+	sb.WriteString(">")
+	if len(ff.Options) > 0 {
+		sb.WriteString("\n" + `<option value=""></option>` + "\n")
+		for _, option := range ff.Options {
+			sb.WriteString(`<option value="` + html.EscapeString(option.Value) + `"`)
+			if option.Value == value {
+				sb.WriteString(" selected")
+			}
+			sb.WriteString(">" + html.EscapeString(option.Label) + "</option>\n")
+		}
+		sb.WriteString("</select>")
+	}
+	sb.WriteString("\n")
+	if errorMessage != "" {
+		sb.WriteString(`<span class="error" id="` + id + `-error">`)
+		sb.WriteString(html.EscapeString(errorMessage))
+		sb.WriteString("</span>\n")
+	}
+	sb.WriteString("</div>\n")
+}
+
+// fieldName returns the input name for the given field.
+//
+// Matches the JSON representation of Address.
+func fieldName(field Field) string {
+	switch field {
+	case FieldLine1:
+		return "line1"
+	case FieldLine2:
+		return "line2"
+	case FieldLine3:
+		return "line3"
+	case FieldSublocality:
+		return "sublocality"
+	case FieldLocality:
+		return "locality"
+	case FieldRegion:
+		return "region"
+	case FieldPostalCode:
+		return "postal_code"
+	}
+	return ""
+}
+
+// labels are the English labels, keyed by label type.
+var labels = map[string]string{
+	// Lines.
+	"line1": "Address line 1",
+	"line2": "Address line 2",
+	"line3": "Address line 3",
+	// Sublocality types.
+	"suburb":           "Suburb",
+	"district":         "District",
+	"neighborhood":     "Neighborhood",
+	"village_township": "Village/Township",
+	"townland":         "Townland",
+	// Locality types.
+	"city":      "City",
+	"post_town": "Post town",
+	"town_city": "Town/City",
+	// Region types.
+	"province":   "Province",
+	"area":       "Area",
+	"canton":     "Canton",
+	"county":     "County",
+	"department": "Department",
+	"do_si":      "Do/Si",
+	"emirate":    "Emirate",
+	"island":     "Island",
+	"parish":     "Parish",
+	"prefecture": "Prefecture",
+	"region":     "Region",
+	"state":      "State",
+	// Postal code types.
+	"postal": "Postal code",
+	"eir":    "Eircode",
+	"pin":    "PIN code",
+	"zip":    "ZIP code",
+}
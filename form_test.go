@@ -0,0 +1,206 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestFormat_FormFields(t *testing.T) {
+	format := address.GetFormat("US")
+	got := format.FormFields(address.NewLocale("en"))
+	if len(got) != 6 {
+		t.Fatalf("got %v fields, want 6", len(got))
+	}
+	wantFields := []address.Field{"1", "2", "3", "L", "R", "P"}
+	wantRows := []int{0, 1, 2, 3, 3, 3}
+	for i, ff := range got {
+		if ff.Field != wantFields[i] {
+			t.Errorf("got %v, want %v", ff.Field, wantFields[i])
+		}
+		if ff.Row != wantRows[i] {
+			t.Errorf("got row %v, want %v", ff.Row, wantRows[i])
+		}
+	}
+
+	line1 := got[0]
+	want := address.FormField{
+		Field:        address.FieldLine1,
+		LabelType:    "line1",
+		Required:     true,
+		MaxLength:    64,
+		Autocomplete: "address-line1",
+		Row:          0,
+	}
+	if !reflect.DeepEqual(line1, want) {
+		t.Errorf("got %v, want %v", line1, want)
+	}
+
+	region := got[4]
+	if region.LabelType != "state" || !region.Required || region.Autocomplete != "address-level1" {
+		t.Errorf("unexpected region field %v", region)
+	}
+	if len(region.Options) != format.Regions.Len() {
+		t.Errorf("got %v options, want %v", len(region.Options), format.Regions.Len())
+	}
+	wantOption := address.FormFieldOption{Value: "AL", Label: "Alabama"}
+	if region.Options[0] != wantOption {
+		t.Errorf("got %v, want %v", region.Options[0], wantOption)
+	}
+
+	postalCode := got[5]
+	if postalCode.LabelType != "zip" || postalCode.Pattern != format.PostalCodePattern {
+		t.Errorf("unexpected postal code field %v", postalCode)
+	}
+}
+
+func TestFormat_FormFieldsLocal(t *testing.T) {
+	format := address.GetFormat("JP")
+	got := format.FormFields(address.NewLocale("ja"))
+	wantFields := []address.Field{"P", "R", "L", "1", "2", "3"}
+	wantRows := []int{0, 1, 1, 2, 3, 4}
+	for i, ff := range got {
+		if ff.Field != wantFields[i] {
+			t.Errorf("got %v, want %v", ff.Field, wantFields[i])
+		}
+		if ff.Row != wantRows[i] {
+			t.Errorf("got row %v, want %v", ff.Row, wantRows[i])
+		}
+	}
+	// Confirm that local regions are used.
+	region := got[1]
+	label, _ := format.LocalRegions.Get(region.Options[0].Value)
+	if region.Options[0].Label != label {
+		t.Errorf("got %v, want %v", region.Options[0].Label, label)
+	}
+}
+
+func TestField_Autocomplete(t *testing.T) {
+	tests := []struct {
+		field address.Field
+		want  string
+	}{
+		{address.FieldLine1, "address-line1"},
+		{address.FieldLine2, "address-line2"},
+		{address.FieldLine3, "address-line3"},
+		{address.FieldSublocality, "address-level3"},
+		{address.FieldLocality, "address-level2"},
+		{address.FieldRegion, "address-level1"},
+		{address.FieldPostalCode, "postal-code"},
+		{address.Field("X"), ""},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tt.field.Autocomplete()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormRenderer_Render(t *testing.T) {
+	locale := address.NewLocale("en")
+	renderer := address.NewFormRenderer(locale)
+
+	// Empty address.
+	got := renderer.Render(address.Address{}, nil)
+	if got != "" {
+		t.Errorf("got: %v, want an empty string", got)
+	}
+
+	// Address with embedded HTML and a validation error.
+	addr := address.Address{
+		Line1:       `Sheikh Zayed Rd "<1>"`,
+		Region:      "DU",
+		CountryCode: "AE",
+	}
+	errs := map[address.Field]string{
+		address.FieldLocality: "Enter a <city>.",
+	}
+	wantLines := []string{
+		`<div class="address-form">`,
+		`<input type="hidden" name="country" value="AE">`,
+		`<div class="address-form-row">`,
+		`<div class="address-form-field line1">`,
+		`<label for="address-line1">Address line 1<span class="required" aria-hidden="true">*</span></label>`,
+		`<input type="text" id="address-line1" name="line1" value="Sheikh Zayed Rd &#34;&lt;1&gt;&#34;" autocomplete="address-line1" required>`,
+		`</div>`,
+		`</div>`,
+		`<div class="address-form-row">`,
+		`<div class="address-form-field line2">`,
+		`<label for="address-line2">Address line 2</label>`,
+		`<input type="text" id="address-line2" name="line2" value="" autocomplete="address-line2">`,
+		`</div>`,
+		`</div>`,
+		`<div class="address-form-row">`,
+		`<div class="address-form-field line3">`,
+		`<label for="address-line3">Address line 3</label>`,
+		`<input type="text" id="address-line3" name="line3" value="" autocomplete="address-line3">`,
+		`</div>`,
+		`</div>`,
+		`<div class="address-form-row">`,
+		`<div class="address-form-field locality has-error">`,
+		`<label for="address-locality">City</label>`,
+		`<input type="text" id="address-locality" name="locality" value="" autocomplete="address-level2" aria-invalid="true" aria-describedby="address-locality-error">`,
+		`<span class="error" id="address-locality-error">Enter a &lt;city&gt;.</span>`,
+		`</div>`,
+		`<div class="address-form-field region">`,
+		`<label for="address-region">Emirate<span class="required" aria-hidden="true">*</span></label>`,
+		`<select id="address-region" name="region" autocomplete="address-level1" required>`,
+		`<option value=""></option>`,
+		`<option value="AZ">Abu Dhabi</option>`,
+		`<option value="AJ">Ajmān</option>`,
+		`<option value="DU" selected>Dubai</option>`,
+		`<option value="FU">Fujairah</option>`,
+		`<option value="RK">Ras Al Khaimah</option>`,
+		`<option value="SH">Sharjah</option>`,
+		`<option value="UQ">Umm Al Quwain</option>`,
+		`</select>`,
+		`</div>`,
+		`</div>`,
+		`</div>`,
+	}
+	got = renderer.Render(addr, errs)
+	want := strings.Join(wantLines, "\n")
+	if string(got) != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestFormRenderer_RenderOptions(t *testing.T) {
+	locale := address.NewLocale("en")
+	renderer := address.NewFormRenderer(locale)
+	renderer.NamePrefix = "shipping_"
+	renderer.IDPrefix = "shipping-"
+	renderer.AutocompleteSection = "shipping"
+	renderer.LabelMapper = func(labelType string, locale address.Locale) string {
+		if labelType == "zip" {
+			return "Postcode"
+		}
+		return labelType
+	}
+	addr := address.Address{
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	got := string(renderer.Render(addr, nil))
+	wantLines := []string{
+		`<div class="address-form-field postal-code">`,
+		`<label for="shipping-postal-code">Postcode<span class="required" aria-hidden="true">*</span></label>`,
+		`<input type="text" id="shipping-postal-code" name="shipping_postal_code" value="94043" autocomplete="shipping postal-code" required pattern="(\d{5})(?:[ \-](\d{4}))?">`,
+		`</div>`,
+	}
+	want := strings.Join(wantLines, "\n")
+	if !strings.Contains(got, want) {
+		t.Errorf("got:\n%v\nwant it to contain:\n%v", got, want)
+	}
+	if !strings.Contains(got, `<input type="hidden" name="shipping_country" value="US">`) {
+		t.Errorf("got:\n%v\nwant it to contain the country input", got)
+	}
+}
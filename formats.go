@@ -240,6 +240,9 @@ var formats = map[string]Format{
 			"SK", "Saskatchewan", "NL", "Terre-Neuve-et-Labrador", "NT", "Territoires du Nord-Ouest",
 			"YT", "Yukon",
 		),
+		// Canada Post limits address lines to 40 characters.
+		MaxLengths: map[Field]int{FieldLine1: 40, FieldLine2: 40, FieldLine3: 40, FieldLocality: 40},
+		Scripts:    []string{"Latn"},
 	},
 	"CC": {
 		Layout:            "%1\n%2\n%3\n%L %P",
@@ -300,6 +303,7 @@ var formats = map[string]Format{
 			"HK", "香港", "XJ", "新疆", "YN", "云南省",
 			"ZJ", "浙江省",
 		),
+		Scripts: []string{"Hani", "Latn"},
 	},
 	"CO": {
 		Layout:            "%1\n%2\n%3\n%L, %R, %P",
@@ -501,6 +505,9 @@ var formats = map[string]Format{
 		Required:          []Field{FieldLine1, FieldLocality, FieldPostalCode},
 		LocalityType:      LocalityTypeTownCity,
 		PostalCodePattern: `GIR ?0AA|(?:(?:AB|AL|B|BA|BB|BD|BF|BH|BL|BN|BR|BS|BT|BX|CA|CB|CF|CH|CM|CO|CR|CT|CV|CW|DA|DD|DE|DG|DH|DL|DN|DT|DY|E|EC|EH|EN|EX|FK|FY|G|GL|GY|GU|HA|HD|HG|HP|HR|HS|HU|HX|IG|IM|IP|IV|JE|KA|KT|KW|KY|L|LA|LD|LE|LL|LN|LS|LU|M|ME|MK|ML|N|NE|NG|NN|NP|NR|NW|OL|OX|PA|PE|PH|PL|PO|PR|RG|RH|RM|S|SA|SE|SG|SK|SL|SM|SN|SO|SP|SR|SS|ST|SW|SY|TA|TD|TF|TN|TQ|TR|TS|TW|UB|W|WA|WC|WD|WF|WN|WR|WS|WV|YO|ZE)(?:\d[\dA-Z]? ?\d[ABD-HJLN-UW-Z]{2}))|BFPO ?\d{1,4}`,
+		// Royal Mail PAF limits post towns to 30 characters.
+		MaxLengths: map[Field]int{FieldLine1: 80, FieldLine2: 80, FieldLine3: 80, FieldLocality: 30},
+		Scripts:    []string{"Latn"},
 	},
 	"GE": {
 		Layout:            "%1\n%2\n%3\n%P %L",
@@ -541,6 +548,7 @@ var formats = map[string]Format{
 		Layout:            "%1\n%2\n%3\n%P %L",
 		Required:          []Field{FieldLine1, FieldLocality, FieldPostalCode},
 		PostalCodePattern: `\d{3} ?\d{2}`,
+		Scripts:           []string{"Grek", "Latn"},
 	},
 	"GS": {
 		Layout:            "%1\n%2\n%3\n%L\n%P",
@@ -836,6 +844,7 @@ var formats = map[string]Format{
 			"43", "熊本県", "44", "大分県", "45", "宮崎県",
 			"46", "鹿児島県", "47", "沖縄県",
 		),
+		Scripts: []string{"Jpan", "Latn"},
 	},
 	"KE": {
 		Layout:            "%1\n%2\n%3\n%L\n%P",
@@ -895,6 +904,7 @@ var formats = map[string]Format{
 			"46", "전남", "45", "전북", "49", "제주",
 			"44", "충남", "43", "충북",
 		),
+		Scripts: []string{"Kore", "Latn"},
 	},
 	"KW": {
 		Layout:            "%1\n%2\n%3\n%P %L",
@@ -1418,6 +1428,7 @@ var formats = map[string]Format{
 			"CE", "Чеченская Республика", "CU", "Чувашская Республика", "CHU", "Чукотский автономный округ",
 			"YAN", "Ямало-Ненецкий автономный округ", "YAR", "Ярославская область",
 		),
+		Scripts: []string{"Cyrl", "Latn"},
 	},
 	"SA": {
 		Layout:            "%1\n%2\n%3\n%L %P",
@@ -1692,6 +1703,7 @@ var formats = map[string]Format{
 			"CYI", "嘉義市", "CYQ", "嘉義縣", "CHA", "彰化縣",
 			"PEN", "澎湖縣",
 		),
+		Scripts: []string{"Hani", "Latn"},
 	},
 	"TZ": {
 		Layout:            "%1\n%2\n%3\n%P %L",
@@ -1726,6 +1738,7 @@ var formats = map[string]Format{
 			"63", "Харківська область", "65", "Херсонська область", "68", "Хмельницька область",
 			"71", "Черкаська область", "77", "Чернівецька область", "74", "Чернігівська область",
 		),
+		Scripts: []string{"Cyrl", "Latn"},
 	},
 	"UM": {
 		Layout:            "%1\n%2\n%3\n%L %R %P",
@@ -1769,6 +1782,9 @@ var formats = map[string]Format{
 			"VA", "Virginia", "WA", "Washington", "WV", "West Virginia",
 			"WI", "Wisconsin", "WY", "Wyoming",
 		),
+		// USPS limits delivery address lines to 64 characters, and city names to 28.
+		MaxLengths: map[Field]int{FieldLine1: 64, FieldLine2: 64, FieldLine3: 64, FieldLocality: 28},
+		Scripts:    []string{"Latn"},
 	},
 	"UY": {
 		Layout:            "%1\n%2\n%3\n%P %L %R",
@@ -5,7 +5,11 @@ package address
 
 import (
 	"html"
+	"io"
+	"reflect"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // Formatter formats addresses for display.
@@ -15,15 +19,62 @@ type Formatter struct {
 	// Can be used to retrieve country names from another (localized) source.
 	// Defaults to a function that uses English country names included in the package.
 	CountryMapper func(countryCode string, locale Locale) string
+	// Registry provides the address formats.
+	// Defaults to nil, in which case the built-in formats are used.
+	Registry *Registry
 	// NoCountry turns off displaying the country name.
 	// Defaults to false.
 	NoCountry bool
+	// OriginCountryCode is the country code of the sender (e.g. "US").
+	// When set, the country name is omitted from domestic addresses, while
+	// international addresses are formatted using the Latin layout and
+	// regions, with the country name in English uppercase, as recommended
+	// by the Universal Postal Union. The viewer locale is ignored in that case.
+	// Addresses in territories served by the same domestic mail (e.g. Puerto
+	// Rico for US senders) are considered domestic, see IsDomestic().
+	// Defaults to an empty string.
+	OriginCountryCode string
+	// Transliterate converts local-script addresses to the Latin script,
+	// using the Latin layout and regions, and the English country name.
+	// See Transliterate() for details.
+	// Defaults to false.
+	Transliterate bool
+	// DetectScript selects the layout and regions based on the script used
+	// by the address, instead of the locale. For example, a Japanese address
+	// written in Latin characters uses the Latin layout, even for "ja" users.
+	// The locale is used for addresses that have no letters.
+	// Defaults to false.
+	DetectScript bool
+	// StructuredData enables structured data markup: schema.org PostalAddress
+	// using microdata or RDFa attributes, or h-adr microformats2 classes.
+	// Defaults to StructuredDataNone.
+	StructuredData StructuredData
+	// Classes maps fields to HTML classes.
+	// Defaults to "line1", "line2", "line3", "sublocality", "locality",
+	// "region", "postal-code". Fields missing from the map use the default.
+	Classes map[Field]string
+	// FieldAttributes returns additional HTML attributes for a field
+	// (e.g. "data-field", "aria-label").
+	// Defaults to nil.
+	FieldAttributes func(field Field) map[string]string
+	// CountryClass is the HTML class of the country.
+	// Defaults to "country".
+	CountryClass string
+	// CountryAttributes returns the HTML attributes of the country.
+	// Defaults to a function that returns the country code as "data-value".
+	CountryAttributes func(countryCode string) map[string]string
 	// WrapperElement is the wrapper HTML element.
-	// Defaults to "p".
+	// Defaults to "p". Use "address" for semantic markup.
 	WrapperElement string
 	// WrapperClass is the wrapper HTML class.
 	// Defaults to "address".
 	WrapperClass string
+	// LineElement is the HTML element wrapping each line (e.g. "span").
+	// Defaults to an empty string, in which case lines are separated by <br>.
+	LineElement string
+	// LineClass is the HTML class of the line element.
+	// Defaults to "address-line".
+	LineClass string
 }
 
 // NewFormatter creates a new formatter for the given locale.
@@ -33,8 +84,15 @@ func NewFormatter(locale Locale) *Formatter {
 		CountryMapper: func(countryCode string, locale Locale) string {
 			return countries[countryCode]
 		},
-		WrapperElement: "p",
-		WrapperClass:   "address",
+		Classes:           make(map[Field]string, len(defaultClasses)),
+		CountryClass:      "country",
+		CountryAttributes: defaultCountryAttributes,
+		WrapperElement:    "p",
+		WrapperClass:      "address",
+		LineClass:         "address-line",
+	}
+	for field, class := range defaultClasses {
+		f.Classes[field] = class
 	}
 	return f
 }
@@ -44,90 +102,264 @@ func (f *Formatter) Locale() Locale {
 	return f.locale
 }
 
-// Format formats the given address.
+// Format formats the given address as HTML.
 func (f *Formatter) Format(addr Address) string {
 	if addr.IsEmpty() {
 		return ""
 	}
-	format := GetFormat(addr.CountryCode)
-	layout := format.SelectLayout(f.locale)
+	sb := strings.Builder{}
+	sb.Grow(256)
+	r := f.getHTMLRenderer()
+	f.Render(&sb, r, addr)
+	putHTMLRenderer(r)
+
+	return sb.String()
+}
+
+// FormatTo formats the given address as HTML, writing it to w.
+//
+// Unlike Format, it avoids building an intermediate string, making it
+// suitable for rendering large numbers of addresses.
+func (f *Formatter) FormatTo(w io.Writer, addr Address) error {
+	if addr.IsEmpty() {
+		return nil
+	}
+	ew := errWriterPool.Get().(*errWriter)
+	ew.w = w
+	r := f.getHTMLRenderer()
+	f.Render(ew, r, addr)
+	putHTMLRenderer(r)
+	err := ew.err
+	*ew = errWriter{}
+	errWriterPool.Put(ew)
+
+	return err
+}
+
+// FormatText formats the given address as plain text, one line per layout line.
+func (f *Formatter) FormatText(addr Address) string {
+	if addr.IsEmpty() {
+		return ""
+	}
+	sb := strings.Builder{}
+	sb.Grow(100)
+	f.Render(&sb, &TextRenderer{}, addr)
+
+	return sb.String()
+}
+
+// Render renders the given address using the given renderer.
+//
+// The address layout is walked one line at a time, skipping any lines and
+// fields that have no values. The country is rendered on its own line,
+// before or after the address depending on the layout, unless NoCountry is set.
+func (f *Formatter) Render(w io.StringWriter, r Renderer, addr Address) {
+	if addr.IsEmpty() {
+		return
+	}
+	format := f.getFormat(addr.CountryCode)
+	locale := f.selectLocale(addr, format)
+	layout := format.SelectLayout(locale)
+	showCountry := !f.NoCountry && (f.OriginCountryCode == "" || f.isInternational(addr.CountryCode))
 	countryBefore := (layout == format.LocalLayout)
 	countryAfter := (layout != format.LocalLayout)
-	country := ""
-	if !f.NoCountry {
-		country = html.EscapeString(f.CountryMapper(addr.CountryCode, f.locale))
-		country = `<span class="country" data-value="` + addr.CountryCode + `">` + country + `</span>`
-	}
-	values := f.getValues(addr)
-	for field, value := range values {
-		if value != "" {
-			value = html.EscapeString(value)
-			value = `<span class="` + f.getClass(field) + `">` + value + `</span>`
-			values[field] = value
+	values := f.getValues(addr, format, locale)
+
+	r.BeginAddress(w, addr)
+	index := 0
+	if showCountry && countryBefore {
+		f.renderCountry(w, r, addr.CountryCode, index)
+		index++
+	}
+	index = f.writeValues(w, r, layout, &values, index)
+	if showCountry && countryAfter {
+		f.renderCountry(w, r, addr.CountryCode, index)
+	}
+	r.EndAddress(w, addr)
+}
+
+// renderCountry renders the country on its own line.
+func (f *Formatter) renderCountry(w io.StringWriter, r Renderer, countryCode string, index int) {
+	r.BeginLine(w, index)
+	r.Country(w, countryCode, f.countryName(countryCode))
+	r.EndLine(w, index)
+}
+
+// countryName returns the name of the given country.
+//
+// International addresses use the English name, in uppercase.
+// Transliterated addresses use the English name.
+func (f *Formatter) countryName(countryCode string) string {
+	if f.isInternational(countryCode) {
+		return strings.ToUpper(f.CountryMapper(countryCode, Locale{Language: "en"}))
+	}
+	if f.Transliterate {
+		return f.CountryMapper(countryCode, Locale{Language: "en"})
+	}
+	return f.CountryMapper(countryCode, f.locale)
+}
+
+// isInternational returns whether the given country differs from the origin country.
+func (f *Formatter) isInternational(countryCode string) bool {
+	return f.OriginCountryCode != "" && !IsDomestic(f.OriginCountryCode, countryCode)
+}
+
+// selectLocale selects the locale used for formatting the given address.
+//
+// International and transliterated addresses use a Latin locale,
+// regardless of the viewer locale. When DetectScript is set, the locale
+// is selected based on the script of the address, if known.
+func (f *Formatter) selectLocale(addr Address, format Format) Locale {
+	latinLocale := Locale{Language: "en", Script: "Latn"}
+	if f.isInternational(addr.CountryCode) || f.Transliterate {
+		return latinLocale
+	}
+	if f.DetectScript {
+		switch addr.Script() {
+		case "":
+			// No letters, fall back to the viewer locale.
+		case "Latn":
+			return latinLocale
+		default:
+			return format.Locale
 		}
 	}
+	return f.locale
+}
+
+// getHTMLRenderer returns an HTML renderer for the formatter.
+//
+// Renderers are pooled to avoid allocations, and must be returned
+// using putHTMLRenderer() once rendering is done.
+func (f *Formatter) getHTMLRenderer() *htmlRenderer {
+	r := htmlRendererPool.Get().(*htmlRenderer)
+	r.f = f
+	return r
+}
+
+// putHTMLRenderer returns the given HTML renderer to the pool.
+func putHTMLRenderer(r *htmlRenderer) {
+	*r = htmlRenderer{}
+	htmlRendererPool.Put(r)
+}
+
+// getFormat returns the address format for the given country code.
+func (f *Formatter) getFormat(countryCode string) Format {
+	if f.Registry != nil {
+		return f.Registry.Get(countryCode)
+	}
+	return GetFormat(countryCode)
+}
 
-	sb := strings.Builder{}
-	sb.Grow(200)
-	sb.WriteString(`<` + f.WrapperElement + ` class="`)
-	sb.WriteString(f.WrapperClass)
-	sb.WriteString(`" translate="no">` + "\n")
-	if !f.NoCountry && countryBefore {
-		sb.WriteString(country)
-		sb.WriteString("<br>\n")
+// writeClass writes the HTML class for the given field.
+func (f *Formatter) writeClass(w io.StringWriter, field Field) {
+	class, ok := f.Classes[field]
+	if !ok {
+		class = defaultClasses[field]
 	}
-	f.writeValues(&sb, layout, values)
-	if !f.NoCountry && countryAfter {
-		sb.WriteString("<br>\n")
-		sb.WriteString(country)
+	w.WriteString(html.EscapeString(class))
+	if f.StructuredData == StructuredDataMicroformats {
+		if mfClass := microformatClass(field); mfClass != "" {
+			w.WriteString(" ")
+			w.WriteString(mfClass)
+		}
 	}
-	sb.WriteString("\n</" + f.WrapperElement + ">")
+}
 
-	return sb.String()
+// writeCountryClass writes the HTML class for the country.
+func (f *Formatter) writeCountryClass(w io.StringWriter) {
+	w.WriteString(html.EscapeString(f.CountryClass))
+	if f.StructuredData == StructuredDataMicroformats {
+		w.WriteString(" p-country-name")
+	}
 }
 
-// getClass returns the HTML class for the given field.
-func (f *Formatter) getClass(field Field) string {
-	var class string
-	switch field {
-	case FieldLine1:
-		class = "line1"
-	case FieldLine2:
-		class = "line2"
-	case FieldLine3:
-		class = "line3"
-	case FieldSublocality:
-		class = "sublocality"
-	case FieldLocality:
-		class = "locality"
-	case FieldRegion:
-		class = "region"
-	case FieldPostalCode:
-		class = "postal-code"
+// writeAttributes writes the HTML attributes for the given field.
+//
+// The schema.org property is omitted if empty.
+func (f *Formatter) writeAttributes(w io.StringWriter, field Field, property string) {
+	switch {
+	case property == "":
+	case f.StructuredData == StructuredDataMicrodata:
+		w.WriteString(` itemprop="`)
+		w.WriteString(property)
+		w.WriteString(`"`)
+	case f.StructuredData == StructuredDataRDFa:
+		w.WriteString(` property="`)
+		w.WriteString(property)
+		w.WriteString(`"`)
+	}
+	if f.FieldAttributes != nil {
+		writeAttributes(w, f.FieldAttributes(field))
+	}
+}
+
+// writeCountryAttributes writes the HTML attributes for the country.
+func (f *Formatter) writeCountryAttributes(w io.StringWriter, countryCode string) {
+	if isDefaultCountryAttributes(f.CountryAttributes) {
+		// Avoid allocating the default attribute map.
+		w.WriteString(` data-value="`)
+		w.WriteString(html.EscapeString(countryCode))
+		w.WriteString(`"`)
+	} else if f.CountryAttributes != nil {
+		writeAttributes(w, f.CountryAttributes(countryCode))
+	}
+	switch f.StructuredData {
+	case StructuredDataMicrodata:
+		w.WriteString(` itemprop="addressCountry"`)
+	case StructuredDataRDFa:
+		w.WriteString(` property="addressCountry" content="`)
+		w.WriteString(html.EscapeString(countryCode))
+		w.WriteString(`"`)
 	}
+}
 
-	return class
+// writeAttributes writes the given HTML attributes, sorted by name.
+func writeAttributes(w io.StringWriter, attrs map[string]string) {
+	if len(attrs) == 0 {
+		return
+	}
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.WriteString(" ")
+		w.WriteString(html.EscapeString(name))
+		w.WriteString(`="`)
+		w.WriteString(html.EscapeString(attrs[name]))
+		w.WriteString(`"`)
+	}
 }
 
-// getValues returns all values for the given address, keyed by field.
+// defaultClasses are the default HTML classes, keyed by field.
+var defaultClasses = map[Field]string{
+	FieldLine1:       "line1",
+	FieldLine2:       "line2",
+	FieldLine3:       "line3",
+	FieldSublocality: "sublocality",
+	FieldLocality:    "locality",
+	FieldRegion:      "region",
+	FieldPostalCode:  "postal-code",
+}
+
+// getValues returns all values for the given address, indexed by field.
 //
 // Region IDs are replaced by region names if available.
-func (f *Formatter) getValues(addr Address) map[Field]string {
-	values := map[Field]string{
-		FieldLine1:       addr.Line1,
-		FieldLine2:       addr.Line2,
-		FieldLine3:       addr.Line3,
-		FieldSublocality: addr.Sublocality,
-		FieldLocality:    addr.Locality,
-		FieldRegion:      addr.Region,
-		FieldPostalCode:  addr.PostalCode,
-	}
-	format := GetFormat(addr.CountryCode)
-	regions := format.SelectRegions(f.locale)
+func (f *Formatter) getValues(addr Address, format Format, locale Locale) fieldValues {
+	if f.Transliterate {
+		addr = transliterate(addr, format)
+	}
+	values := fieldValues{
+		addr.Line1, addr.Line2, addr.Line3, addr.Sublocality,
+		addr.Locality, addr.Region, addr.PostalCode,
+	}
+	regions := format.SelectRegions(locale)
 	if !format.ShowRegionID && regions.Len() > 0 {
 		region, ok := regions.Get(addr.Region)
 		if ok {
-			values[FieldRegion] = region
+			values[fieldIndexRegion] = region
 		}
 	}
 
@@ -136,8 +368,9 @@ func (f *Formatter) getValues(addr Address) map[Field]string {
 
 // writeValues writes the formatted address one line at a time, skipping any
 // lines that have no values.
-func (f *Formatter) writeValues(b *strings.Builder, layout string, values map[Field]string) {
-	written := false
+//
+// Returns the index of the next line.
+func (f *Formatter) writeValues(w io.StringWriter, r Renderer, layout string, values *fieldValues, index int) int {
 	for len(layout) > 0 {
 		line := layout
 		if n := strings.IndexByte(layout, '\n'); n >= 0 {
@@ -148,18 +381,19 @@ func (f *Formatter) writeValues(b *strings.Builder, layout string, values map[Fi
 		if !hasValues(line, values) {
 			continue
 		}
-		if written {
-			b.WriteString("<br>\n")
-		}
-		writeLine(b, line, values)
-		written = true
+		r.BeginLine(w, index)
+		writeLine(w, r, line, values)
+		r.EndLine(w, index)
+		index++
 	}
+
+	return index
 }
 
 // hasValues reports whether a line of the layout has at least one value.
-func hasValues(line string, values map[Field]string) bool {
+func hasValues(line string, values *fieldValues) bool {
 	for i := 0; i+1 < len(line); i++ {
-		if line[i] == '%' && values[Field(line[i+1:i+2])] != "" {
+		if line[i] == '%' && values.get(Field(line[i+1:i+2])) != "" {
 			return true
 		}
 	}
@@ -171,7 +405,7 @@ func hasValues(line string, values map[Field]string) bool {
 //
 // Empty fields are left out, along with any separator between them and the
 // next field that has a value.
-func writeLine(b *strings.Builder, line string, values map[Field]string) {
+func writeLine(w io.StringWriter, r Renderer, line string, values *fieldValues) {
 	prev := 0
 	sep := ""
 	written := false
@@ -187,15 +421,86 @@ func writeLine(b *strings.Builder, line string, values map[Field]string) {
 			sep = line[prev:i]
 		}
 		prev = i + 2
-		if values[field] == "" {
+		value := values.get(field)
+		if value == "" {
 			skipped = true
 			continue
 		}
-		if written || !skipped {
-			b.WriteString(sep)
+		if (written || !skipped) && sep != "" {
+			r.Separator(w, sep)
 		}
-		b.WriteString(values[field])
+		r.Field(w, field, value)
 		written = true
 		skipped = false
 	}
 }
+
+// fieldValues holds the values of an address, indexed by field.
+//
+// Used instead of a map[Field]string to avoid allocations.
+type fieldValues [7]string
+
+const fieldIndexRegion = 5
+
+// get returns the value of the given field.
+func (v *fieldValues) get(field Field) string {
+	switch field {
+	case FieldLine1:
+		return v[0]
+	case FieldLine2:
+		return v[1]
+	case FieldLine3:
+		return v[2]
+	case FieldSublocality:
+		return v[3]
+	case FieldLocality:
+		return v[4]
+	case FieldRegion:
+		return v[fieldIndexRegion]
+	case FieldPostalCode:
+		return v[6]
+	}
+	return ""
+}
+
+// defaultCountryAttributes returns the country code as "data-value".
+func defaultCountryAttributes(countryCode string) map[string]string {
+	return map[string]string{"data-value": countryCode}
+}
+
+// isDefaultCountryAttributes returns whether fn is defaultCountryAttributes.
+func isDefaultCountryAttributes(fn func(countryCode string) map[string]string) bool {
+	return fn != nil && reflect.ValueOf(fn).Pointer() == reflect.ValueOf(defaultCountryAttributes).Pointer()
+}
+
+// htmlRendererPool reuses htmlRenderers between Format and FormatTo calls.
+var htmlRendererPool = sync.Pool{
+	New: func() interface{} {
+		return &htmlRenderer{}
+	},
+}
+
+// errWriterPool reuses errWriters between FormatTo calls.
+var errWriterPool = sync.Pool{
+	New: func() interface{} {
+		return &errWriter{}
+	},
+}
+
+// errWriter wraps an io.Writer, recording the first write error.
+//
+// Subsequent writes are skipped once an error has occurred.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+// WriteString implements the io.StringWriter interface.
+func (ew *errWriter) WriteString(s string) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	var n int
+	n, ew.err = io.WriteString(ew.w, s)
+	return n, ew.err
+}
@@ -4,6 +4,8 @@
 package address_test
 
 import (
+	"bytes"
+	"errors"
 	"strings"
 	"testing"
 
@@ -300,3 +302,342 @@ func TestFormatter_FormatTR(t *testing.T) {
 		t.Errorf("got:\n%v\nwant:\n%v", got, want)
 	}
 }
+
+func TestFormatter_FormatOriginCountry(t *testing.T) {
+	locale := address.NewLocale("zh")
+	formatter := address.NewFormatter(locale)
+	formatter.OriginCountryCode = "CN"
+	formatter.CountryMapper = func(countryCode string, locale address.Locale) string {
+		if countryCode == "CN" && locale.Language == "zh" {
+			return "中国"
+		}
+		countries := address.GetCountryNames()
+		return countries[countryCode]
+	}
+
+	// Domestic addresses have no country.
+	addr := address.Address{
+		Line1:       "幸福中路",
+		Sublocality: "新城区",
+		Locality:    "西安市",
+		Region:      "SN",
+		PostalCode:  "710043",
+		CountryCode: "CN",
+	}
+	wantLines := []string{
+		`<p class="address" translate="no">`,
+		`<span class="postal-code">710043</span><br>`,
+		`<span class="region">陕西省</span><span class="locality">西安市</span><span class="sublocality">新城区</span><br>`,
+		`<span class="line1">幸福中路</span>`,
+		`</p>`,
+	}
+	got := formatter.Format(addr)
+	want := strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	// International addresses use the Latin layout, regardless of the locale.
+	formatter.OriginCountryCode = "US"
+	addr = address.Address{
+		Line1:       "Xing Fu Zhong Lu",
+		Sublocality: "Xincheng Qu",
+		Locality:    "Xi'an Shi",
+		Region:      "SN",
+		PostalCode:  "710043",
+		CountryCode: "CN",
+	}
+	wantLines = []string{
+		`<p class="address" translate="no">`,
+		`<span class="line1">Xing Fu Zhong Lu</span><br>`,
+		`<span class="sublocality">Xincheng Qu</span><br>`,
+		`<span class="locality">Xi&#39;an Shi</span><br>`,
+		`<span class="region">Shaanxi Sheng</span>, <span class="postal-code">710043</span><br>`,
+		`<span class="country" data-value="CN">CHINA</span>`,
+		`</p>`,
+	}
+	got = formatter.Format(addr)
+	want = strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	// Territories served by the same domestic mail have no country.
+	formatter.OriginCountryCode = "US"
+	addr = address.Address{
+		Line1:       "1 Calle Fortaleza",
+		Locality:    "San Juan",
+		PostalCode:  "00901",
+		CountryCode: "PR",
+	}
+	got = formatter.FormatText(addr)
+	want = "1 Calle Fortaleza\nSan Juan 00901"
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	// NoCountry takes precedence.
+	addr = address.Address{
+		Line1:       "Xing Fu Zhong Lu",
+		Sublocality: "Xincheng Qu",
+		Locality:    "Xi'an Shi",
+		Region:      "SN",
+		PostalCode:  "710043",
+		CountryCode: "CN",
+	}
+	formatter.NoCountry = true
+	got = formatter.FormatText(addr)
+	want = "Xing Fu Zhong Lu\nXincheng Qu\nXi'an Shi\nShaanxi Sheng, 710043"
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestFormatter_FormatCustomMarkup(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	formatter.Classes[address.FieldLine1] = "address__line1"
+	formatter.Classes[address.FieldPostalCode] = "address__postal-code"
+	formatter.FieldAttributes = func(field address.Field) map[string]string {
+		attrs := map[string]string{"data-field": string(field)}
+		if field == address.FieldPostalCode {
+			attrs["aria-label"] = "ZIP code"
+		}
+		return attrs
+	}
+	formatter.CountryClass = "address__country"
+	formatter.CountryAttributes = func(countryCode string) map[string]string {
+		return map[string]string{"data-field": "country", "data-code": countryCode}
+	}
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	wantLines := []string{
+		`<p class="address" translate="no">`,
+		`<span class="address__line1" data-field="1">1098 Alta Ave</span><br>`,
+		`<span class="locality" data-field="L">Mountain View</span>, <span class="region" data-field="R">CA</span> <span class="address__postal-code" aria-label="ZIP code" data-field="P">94043</span><br>`,
+		`<span class="address__country" data-code="US" data-field="country">United States</span>`,
+		`</p>`,
+	}
+	got := formatter.Format(addr)
+	want := strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	// No country attributes.
+	formatter = address.NewFormatter(locale)
+	formatter.CountryAttributes = nil
+	wantLines = []string{
+		`<p class="address" translate="no">`,
+		`<span class="line1">1098 Alta Ave</span><br>`,
+		`<span class="locality">Mountain View</span>, <span class="region">CA</span> <span class="postal-code">94043</span><br>`,
+		`<span class="country">United States</span>`,
+		`</p>`,
+	}
+	got = formatter.Format(addr)
+	want = strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestFormatter_FormatSemanticMarkup(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	formatter.WrapperElement = "address"
+	formatter.LineElement = "span"
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	wantLines := []string{
+		`<address class="address" translate="no">`,
+		`<span class="address-line"><span class="line1">1098 Alta Ave</span></span>`,
+		`<span class="address-line"><span class="locality">Mountain View</span>, <span class="region">CA</span> <span class="postal-code">94043</span></span>`,
+		`<span class="address-line"><span class="country" data-value="US">United States</span></span>`,
+		`</address>`,
+	}
+	got := formatter.Format(addr)
+	want := strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	// Country before the address, no line class.
+	addr = address.Address{
+		Line1:       "幸福中路",
+		Locality:    "西安市",
+		Region:      "SN",
+		PostalCode:  "710043",
+		CountryCode: "CN",
+	}
+	formatter = address.NewFormatter(address.NewLocale("zh"))
+	formatter.LineElement = "div"
+	formatter.LineClass = ""
+	wantLines = []string{
+		`<p class="address" translate="no">`,
+		`<div><span class="country" data-value="CN">China</span></div>`,
+		`<div><span class="postal-code">710043</span></div>`,
+		`<div><span class="region">陕西省</span><span class="locality">西安市</span></div>`,
+		`<div><span class="line1">幸福中路</span></div>`,
+		`</p>`,
+	}
+	got = formatter.Format(addr)
+	want = strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestFormatter_Copy(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	copied := *formatter
+	copied.CountryAttributes = func(countryCode string) map[string]string {
+		return map[string]string{"data-country": countryCode}
+	}
+	copied.WrapperElement = "address"
+
+	got := copied.Format(addr)
+	wantLines := []string{
+		`<address class="address" translate="no">`,
+		`<span class="line1">1098 Alta Ave</span><br>`,
+		`<span class="locality">Mountain View</span>, <span class="region">CA</span> <span class="postal-code">94043</span><br>`,
+		`<span class="country" data-country="US">United States</span>`,
+		`</address>`,
+	}
+	want := strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := copied.FormatTo(&buf, addr); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Errorf("got:\n%v\nwant:\n%v", buf.String(), want)
+	}
+
+	// The original formatter is unchanged.
+	if got := formatter.Format(addr); !strings.Contains(got, `<span class="country" data-value="US">`) {
+		t.Errorf("got:\n%v\nwant the default country attributes", got)
+	}
+}
+
+func TestFormatter_FormatTo(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	buf := bytes.Buffer{}
+	err := formatter.FormatTo(&buf, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), formatter.Format(addr); got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	// Empty address.
+	buf.Reset()
+	err = formatter.FormatTo(&buf, address.Address{})
+	if err != nil || buf.Len() != 0 {
+		t.Errorf("got %q, %v, want an empty string, nil", buf.String(), err)
+	}
+
+	// Write error.
+	err = formatter.FormatTo(failingWriter{}, addr)
+	if err != errWrite {
+		t.Errorf("got %v, want %v", err, errWrite)
+	}
+
+	// The default settings should not allocate.
+	allocs := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		formatter.FormatTo(&buf, addr)
+	})
+	if allocs > 0 {
+		t.Errorf("got %v allocs, want 0", allocs)
+	}
+}
+
+var errWrite = errors.New("write failed")
+
+// failingWriter is an io.Writer that always fails.
+type failingWriter struct{}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, errWrite
+}
+
+func BenchmarkFormatter_Format(b *testing.B) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		formatter.Format(addr)
+	}
+}
+
+func BenchmarkFormatter_FormatTo(b *testing.B) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	buf := bytes.Buffer{}
+	buf.Grow(4096)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		buf.Reset()
+		formatter.FormatTo(&buf, addr)
+	}
+}
+
+func BenchmarkFormatter_FormatText(b *testing.B) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		formatter.FormatText(addr)
+	}
+}
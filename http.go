@@ -11,19 +11,28 @@ import (
 
 // FormatHandler is an HTTP handler for serving address formats.
 //
-// Response size is ~47kb, or ~14kb if gzip compression is used.
+// Response size is ~50kb, or ~16kb if gzip compression is used.
 //
 // The locale can be provided either as a query string (?locale=fr)
 // or as a header (Accept-Language:fr). Defaults to "en".
-type FormatHandler struct{}
+//
+// The parsed layouts can be included as "layout_lines" by providing
+// the ?layout_lines=1 query string. This roughly doubles the response size.
+type FormatHandler struct {
+	// Registry provides the address formats.
+	// Defaults to nil, in which case the built-in formats are used.
+	Registry *Registry
+}
 
 // ServeHTTP implements the http.Handler interface.
 func (h *FormatHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	locale := h.getLocale(r)
+	locale := getLocale(r)
+	includeLayoutLines := r.URL.Query().Get("layout_lines") == "1"
 	// Preselecting the layout and regions reduces HTTP request size by ~20%.
 	type localizedFormat struct {
 		Locale            string           `json:"locale,omitempty"`
 		Layout            string           `json:"layout,omitempty"`
+		LayoutLines       []LayoutLine     `json:"layout_lines,omitempty"`
 		Required          []Field          `json:"required,omitempty"`
 		Defaults          map[Field]string `json:"defaults,omitempty"`
 		SublocalityType   SublocalityType  `json:"sublocality_type,omitempty"`
@@ -33,12 +42,16 @@ func (h *FormatHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		PostalCodePattern string           `json:"postal_code_pattern,omitempty"`
 		ShowRegionID      bool             `json:"show_region_id,omitempty"`
 		Regions           *RegionMap       `json:"regions,omitempty"`
+		MaxLengths        map[Field]int    `json:"max_lengths,omitempty"`
+		Scripts           []string         `json:"scripts,omitempty"`
 	}
+	formats := h.getFormats()
 	data := make(map[string]localizedFormat, len(formats))
 	for countryCode, format := range formats {
+		layout := format.SelectLayout(locale)
 		lf := localizedFormat{
 			Locale:            format.Locale.String(),
-			Layout:            format.SelectLayout(locale),
+			Layout:            layout,
 			Required:          format.Required,
 			Defaults:          format.Defaults,
 			SublocalityType:   format.SublocalityType,
@@ -47,13 +60,22 @@ func (h *FormatHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			PostalCodeType:    format.PostalCodeType,
 			PostalCodePattern: format.PostalCodePattern,
 			ShowRegionID:      format.ShowRegionID,
+			MaxLengths:        format.MaxLengths,
+			Scripts:           format.Scripts,
+		}
+		if includeLayoutLines {
+			lf.LayoutLines = ParseLayout(layout).Lines
 		}
 		if regions := format.SelectRegions(locale); regions.Len() > 0 {
 			lf.Regions = &regions
 		}
 		data[countryCode] = lf
 	}
-	jsonData, _ := json.Marshal(data)
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		http.Error(w, "failed to encode the address formats", http.StatusInternalServerError)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Content-Language", locale.String())
@@ -61,13 +83,66 @@ func (h *FormatHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Write(jsonData)
 }
 
+// getFormats returns all address formats, keyed by country code.
+func (h *FormatHandler) getFormats() map[string]Format {
+	if h.Registry != nil {
+		return h.Registry.GetAll()
+	}
+	return GetFormats()
+}
+
+// FormHandler is an HTTP handler for serving address form fields.
+//
+// The country code must be provided as a query string (?country=US).
+// The locale can be provided either as a query string (?locale=fr)
+// or as a header (Accept-Language:fr). Defaults to "en".
+type FormHandler struct {
+	// Registry provides the address formats.
+	// Defaults to nil, in which case the built-in formats are used.
+	Registry *Registry
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *FormHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	countryCode := strings.ToUpper(r.URL.Query().Get("country"))
+	if countryCode == "" || !h.checkCountryCode(countryCode) {
+		http.Error(w, "invalid or missing country code", http.StatusBadRequest)
+		return
+	}
+	locale := getLocale(r)
+	var format Format
+	if h.Registry != nil {
+		format = h.Registry.Get(countryCode)
+	} else {
+		format = GetFormat(countryCode)
+	}
+	jsonData, err := json.Marshal(format.FormFields(locale))
+	if err != nil {
+		http.Error(w, "failed to encode the form fields", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", locale.String())
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonData)
+}
+
+// checkCountryCode checks whether the given country code is valid.
+func (h *FormHandler) checkCountryCode(countryCode string) bool {
+	if h.Registry != nil {
+		return h.Registry.CheckCountryCode(countryCode)
+	}
+	return CheckCountryCode(countryCode)
+}
+
 // getLocale returns the locale to use.
 //
 // Priority:
 // 1) Query string (?locale=fr)
 // 2) Header (Accept-Language=fr)
 // 3) English
-func (h *FormatHandler) getLocale(r *http.Request) Locale {
+func getLocale(r *http.Request) Locale {
 	var locale Locale
 	if param := r.URL.Query().Get("locale"); param != "" {
 		locale = NewLocale(param)
@@ -15,10 +15,11 @@ import (
 
 // testFormat is a reduced format for testing purposes.
 type testFormat struct {
-	Locale     string            `json:"locale"`
-	Layout     string            `json:"layout"`
-	RegionType string            `json:"region_type"`
-	Regions    map[string]string `json:"regions"`
+	Locale      string               `json:"locale"`
+	Layout      string               `json:"layout"`
+	LayoutLines []address.LayoutLine `json:"layout_lines"`
+	RegionType  string               `json:"region_type"`
+	Regions     map[string]string    `json:"regions"`
 }
 
 func TestFormatHandlerNoLocale(t *testing.T) {
@@ -65,11 +66,34 @@ func TestFormatHandlerNoLocale(t *testing.T) {
 	if format.Layout != wantFormat.Layout {
 		t.Errorf("got %q, want %q", format.Layout, wantFormat.Layout)
 	}
+	if format.LayoutLines != nil {
+		t.Errorf("got %v, want no layout lines", format.LayoutLines)
+	}
 	if !reflect.DeepEqual(format.Regions, wantRegions) {
 		t.Errorf("got %v, want %v", format.Regions, wantRegions)
 	}
 }
 
+func TestFormatHandlerLayoutLines(t *testing.T) {
+	req, err := http.NewRequest("GET", "/address-formats?layout_lines=1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler := address.FormatHandler{}
+	handler.ServeHTTP(rr, req)
+
+	var data map[string]testFormat
+	err = json.Unmarshal(rr.Body.Bytes(), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantLines := address.ParseLayout(address.GetFormat("US").Layout).Lines
+	if !reflect.DeepEqual(data["US"].LayoutLines, wantLines) {
+		t.Errorf("got %v, want %v", data["US"].LayoutLines, wantLines)
+	}
+}
+
 func TestFormatHandlerLocaleQuery(t *testing.T) {
 	req, err := http.NewRequest("GET", "/address-formats?locale=zh", nil)
 	if err != nil {
@@ -154,3 +178,49 @@ func TestFormatHandlerLocaleHeader(t *testing.T) {
 		t.Errorf("got %v, want %v", format.Regions, wantRegions)
 	}
 }
+
+func TestFormHandler(t *testing.T) {
+	req, err := http.NewRequest("GET", "/address-form?country=jp&locale=ja", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler := address.FormHandler{}
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("got HTTP %v want HTTP %v", status, http.StatusOK)
+	}
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("got %v want %v", contentType, "application/json")
+	}
+	if contentLanguage := rr.Header().Get("Content-Language"); contentLanguage != "ja" {
+		t.Errorf("got %v want %v", contentLanguage, "ja")
+	}
+
+	var data []address.FormField
+	err = json.Unmarshal(rr.Body.Bytes(), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := address.GetFormat("JP").FormFields(address.NewLocale("ja"))
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("got %v, want %v", data, want)
+	}
+}
+
+func TestFormHandlerInvalidCountry(t *testing.T) {
+	for _, url := range []string{"/address-form", "/address-form?country=XY"} {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := httptest.NewRecorder()
+		handler := address.FormHandler{}
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("got HTTP %v want HTTP %v", status, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ISO20022Structure represents the structure of an ISO 20022 postal address.
+type ISO20022Structure uint8
+
+const (
+	// ISO20022Structured addresses only use structured elements.
+	ISO20022Structured ISO20022Structure = iota
+	// ISO20022Hybrid addresses have a structured town name and country,
+	// along with at most two address lines.
+	ISO20022Hybrid
+	// ISO20022Unstructured addresses use address lines. They are being
+	// phased out of payment messages (e.g. SEPA, CBPR+).
+	ISO20022Unstructured
+)
+
+var iso20022StructureNames = [...]string{"structured", "hybrid", "unstructured"}
+
+// String returns the string representation of s.
+func (s ISO20022Structure) String() string {
+	if int(s) >= len(iso20022StructureNames) {
+		return ""
+	}
+	return iso20022StructureNames[s]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s ISO20022Structure) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *ISO20022Structure) UnmarshalText(b []byte) error {
+	aux := string(b)
+	for i, name := range iso20022StructureNames {
+		if name == aux {
+			*s = ISO20022Structure(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid ISO 20022 structure %q", aux)
+}
+
+// ISO20022PostalAddress represents an ISO 20022 postal address (PostalAddress24).
+//
+// The XML element names match the ISO 20022 tags. No namespace is used,
+// since it depends on the message (e.g. pain.001.001.09).
+// Element lengths are limited, use Validate to check them.
+type ISO20022PostalAddress struct {
+	Department         string   `xml:"Dept,omitempty" json:"Dept,omitempty"`
+	SubDepartment      string   `xml:"SubDept,omitempty" json:"SubDept,omitempty"`
+	StreetName         string   `xml:"StrtNm,omitempty" json:"StrtNm,omitempty"`
+	BuildingNumber     string   `xml:"BldgNb,omitempty" json:"BldgNb,omitempty"`
+	BuildingName       string   `xml:"BldgNm,omitempty" json:"BldgNm,omitempty"`
+	Floor              string   `xml:"Flr,omitempty" json:"Flr,omitempty"`
+	PostBox            string   `xml:"PstBx,omitempty" json:"PstBx,omitempty"`
+	Room               string   `xml:"Room,omitempty" json:"Room,omitempty"`
+	PostCode           string   `xml:"PstCd,omitempty" json:"PstCd,omitempty"`
+	TownName           string   `xml:"TwnNm,omitempty" json:"TwnNm,omitempty"`
+	TownLocationName   string   `xml:"TwnLctnNm,omitempty" json:"TwnLctnNm,omitempty"`
+	DistrictName       string   `xml:"DstrctNm,omitempty" json:"DstrctNm,omitempty"`
+	CountrySubDivision string   `xml:"CtrySubDvsn,omitempty" json:"CtrySubDvsn,omitempty"`
+	Country            string   `xml:"Ctry,omitempty" json:"Ctry,omitempty"`
+	AddressLines       []string `xml:"AdrLine,omitempty" json:"AdrLine,omitempty"`
+}
+
+// NewISO20022PostalAddress converts the given address to an ISO 20022 postal address.
+//
+// The first address line is split into the street name and building number
+// (e.g. "1098 Alta Ave" into "Alta Ave" and "1098"), while the remaining
+// lines become address lines, producing a hybrid address. If the address
+// has no other lines, the result is fully structured. The sublocality is
+// used as the town location name.
+//
+// The line is only split if the building number is on the side used by
+// the country (see Address), and the rest of the line is clearly a street
+// name. Otherwise the whole line is used as the street name (e.g. "Route 66",
+// "Unit 2050", "Calle 5 10"). A first line containing a PO box is used as
+// the post box instead.
+func NewISO20022PostalAddress(addr Address) ISO20022PostalAddress {
+	p := ISO20022PostalAddress{
+		TownName:           addr.Locality,
+		TownLocationName:   addr.Sublocality,
+		CountrySubDivision: addr.Region,
+		PostCode:           addr.PostalCode,
+		Country:            addr.CountryCode,
+	}
+	if isPOBox(addr.CountryCode, addr.Line1) {
+		p.PostBox = strings.TrimSpace(addr.Line1)
+	} else {
+		p.StreetName, p.BuildingNumber = splitStreet(addr.CountryCode, addr.Line1)
+	}
+	for _, line := range []string{addr.Line2, addr.Line3} {
+		if line != "" {
+			p.AddressLines = append(p.AddressLines, line)
+		}
+	}
+	return p
+}
+
+// Structure returns the structure of the postal address.
+//
+// Addresses without a town name or country are unstructured, even if
+// they contain other structured elements. Element lengths are not
+// checked, see Validate.
+func (p ISO20022PostalAddress) Structure() ISO20022Structure {
+	if p.TownName == "" || p.Country == "" {
+		return ISO20022Unstructured
+	}
+	if len(p.AddressLines) > 0 {
+		return ISO20022Hybrid
+	}
+	return ISO20022Structured
+}
+
+// Validate checks the postal address against the ISO 20022 limits.
+//
+// Elements are limited to 70 characters (Dept, SubDept, StrtNm, Flr, Room,
+// AdrLine), 16 characters (BldgNb, PstBx, PstCd) or 35 characters (the
+// others). Ctry must be a two-letter uppercase code. At most 7 address
+// lines are allowed, or 2 for hybrid addresses.
+func (p ISO20022PostalAddress) Validate() error {
+	var messages []string
+	checkLength := func(tag, value string, maxLength int) {
+		if n := utf8.RuneCountInString(value); n > maxLength {
+			messages = append(messages, fmt.Sprintf("%v is longer than %d characters", tag, maxLength))
+		}
+	}
+	checkLength("Dept", p.Department, 70)
+	checkLength("SubDept", p.SubDepartment, 70)
+	checkLength("StrtNm", p.StreetName, 70)
+	checkLength("BldgNb", p.BuildingNumber, 16)
+	checkLength("BldgNm", p.BuildingName, 35)
+	checkLength("Flr", p.Floor, 70)
+	checkLength("PstBx", p.PostBox, 16)
+	checkLength("Room", p.Room, 70)
+	checkLength("PstCd", p.PostCode, 16)
+	checkLength("TwnNm", p.TownName, 35)
+	checkLength("TwnLctnNm", p.TownLocationName, 35)
+	checkLength("DstrctNm", p.DistrictName, 35)
+	checkLength("CtrySubDvsn", p.CountrySubDivision, 35)
+	if p.Country != "" && !iso20022CountryRe.MatchString(p.Country) {
+		messages = append(messages, fmt.Sprintf("Ctry %q is not a two-letter country code", p.Country))
+	}
+	for _, line := range p.AddressLines {
+		checkLength("AdrLine", line, 70)
+	}
+	maxLines := 7
+	if p.Structure() == ISO20022Hybrid {
+		maxLines = 2
+	}
+	if len(p.AddressLines) > maxLines {
+		messages = append(messages, fmt.Sprintf("more than %d AdrLine elements", maxLines))
+	}
+	if len(messages) > 0 {
+		return fmt.Errorf("invalid ISO 20022 postal address: %v", strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// Address converts the postal address to an address.
+//
+// The street name and building number are joined using the order of the
+// country (e.g. "Alta Ave" and "1098" into "1098 Alta Ave" in the US,
+// "Calle Numa" and "55" into "Calle Numa 55" in Spain). The building name,
+// floor, room and post box are joined into the next line, followed by the
+// address lines. Unstructured addresses are expected to have their lines
+// in address order, with the last line containing the postal code and town.
+// Lines that don't fit into the three address lines are joined into the third.
+func (p ISO20022PostalAddress) Address() Address {
+	countryCode := strings.ToUpper(p.Country)
+	var lines []string
+	if street := joinStreet(countryCode, p.StreetName, p.BuildingNumber); street != "" {
+		lines = append(lines, street)
+	}
+	var details []string
+	for _, value := range []string{p.BuildingName, p.Floor, p.Room, p.PostBox} {
+		if value != "" {
+			details = append(details, value)
+		}
+	}
+	if len(details) > 0 {
+		lines = append(lines, strings.Join(details, ", "))
+	}
+	addressLines := p.AddressLines
+	addr := Address{
+		Sublocality: p.TownLocationName,
+		Locality:    p.TownName,
+		Region:      lookupRegion(GetFormat(countryCode), p.CountrySubDivision),
+		PostalCode:  p.PostCode,
+		CountryCode: countryCode,
+	}
+	if addr.Locality == "" && len(addressLines) > 1 && len(lines) == 0 {
+		// Unstructured address, the last line holds the postal code and town.
+		last := addressLines[len(addressLines)-1]
+		addressLines = addressLines[:len(addressLines)-1]
+		addr.PostalCode, addr.Locality = splitPostalCodeTown(last)
+		if p.PostCode != "" {
+			addr.PostalCode = p.PostCode
+		}
+	}
+	for _, line := range addressLines {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > 0 {
+		addr.Line1 = lines[0]
+	}
+	if len(lines) > 1 {
+		addr.Line2 = lines[1]
+	}
+	if len(lines) > 2 {
+		addr.Line3 = strings.Join(lines[2:], ", ")
+	}
+	return addr
+}
+
+// buildingNumberPattern matches building numbers, such as "55", "12A", "12/A", "7-9", "21/3".
+const buildingNumberPattern = `\d+[A-Za-z]?(?:[-/](?:\d+[A-Za-z]?|[A-Za-z]))?`
+
+var (
+	leadingBuildingNumberRe  = regexp.MustCompile(`^(` + buildingNumberPattern + `),?\s+(.+)$`)
+	trailingBuildingNumberRe = regexp.MustCompile(`^(.+?),?\s+(?:[Nn][°ºo]\.?\s*)?(` + buildingNumberPattern + `)$`)
+	iso20022CountryRe        = regexp.MustCompile(`^[A-Z]{2}$`)
+	postalCodeTownRe         = regexp.MustCompile(`^([A-Z]{0,2}-?\d[\dA-Z -]*?\d[A-Z]{0,2})\s+(\D.*)$`)
+	// Street names that start or end with a number, which could also be
+	// the building number (e.g. "Calle 5 10", "10 5 Mile Road").
+	leadingNumberRe  = regexp.MustCompile(`^` + buildingNumberPattern + `(?:\s|$)`)
+	trailingNumberRe = regexp.MustCompile(`(?:^|\s)` + buildingNumberPattern + `$`)
+)
+
+// splitStreet splits the given address line into the street name and building number.
+//
+// The building number is recognized at the start of the line in countries
+// where it precedes the street name, and at the end of the line elsewhere.
+// The whole line is returned as the street name if no building number was
+// found, or if the split would be ambiguous.
+func splitStreet(countryCode, line string) (streetName, buildingNumber string) {
+	line = strings.TrimSpace(line)
+	if contains(buildingNumberFirstCountryCodes, strings.ToUpper(countryCode)) {
+		m := leadingBuildingNumberRe.FindStringSubmatch(line)
+		if m == nil || leadingNumberRe.MatchString(m[2]) || isStreetDesignator(m[2]) {
+			return line, ""
+		}
+		return m[2], m[1]
+	}
+	m := trailingBuildingNumberRe.FindStringSubmatch(line)
+	if m == nil || trailingNumberRe.MatchString(m[1]) || isStreetDesignator(m[1]) {
+		return line, ""
+	}
+	return m[1], m[2]
+}
+
+// isStreetDesignator returns whether the given value is a designator that
+// is followed by a number, such as "Route" or "Unit".
+func isStreetDesignator(value string) bool {
+	return contains(streetDesignators, strings.ToLower(strings.TrimSuffix(value, ".")))
+}
+
+// isPOBox returns whether the given address line contains a PO box.
+func isPOBox(countryCode, line string) bool {
+	if line == "" {
+		return false
+	}
+	patterns := append([]*regexp.Regexp{poBoxPatterns["en"]}, poBoxPatternsByCountry(strings.ToUpper(countryCode))...)
+	for _, pattern := range patterns {
+		if pattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinStreet joins the given street name and building number, in the order of the given country.
+func joinStreet(countryCode, streetName, buildingNumber string) string {
+	streetName = strings.TrimSpace(streetName)
+	buildingNumber = strings.TrimSpace(buildingNumber)
+	if streetName == "" || buildingNumber == "" {
+		return streetName + buildingNumber
+	}
+	if contains(buildingNumberFirstCountryCodes, countryCode) {
+		return buildingNumber + " " + streetName
+	}
+	return streetName + " " + buildingNumber
+}
+
+// splitPostalCodeTown splits an unstructured address line into the postal code and town.
+//
+// The whole line is returned as the town if no postal code was found.
+func splitPostalCodeTown(line string) (postalCode, town string) {
+	line = strings.TrimSpace(line)
+	if m := postalCodeTownRe.FindStringSubmatch(line); m != nil {
+		return m[1], m[2]
+	}
+	return "", line
+}
+
+// buildingNumberFirstCountryCodes are the countries where the building
+// number precedes the street name.
+var buildingNumberFirstCountryCodes = []string{
+	"AU", "CA", "FR", "GB", "IE", "IL", "IN", "LU", "MY", "NZ", "PH", "SG", "US", "ZA",
+	"AS", "GU", "MP", "PR", "VI", "GF", "GP", "MQ", "RE", "YT", "MC",
+}
+
+// streetDesignators are the lowercase words that form a street name or
+// unit together with the following number (e.g. "Route 66", "Unit 2050").
+var streetDesignators = []string{
+	"apartment", "apt", "avenida", "av", "bldg", "building", "calle", "carrera",
+	"county road", "flat", "floor", "highway", "hwy", "interstate", "lot", "route",
+	"rte", "ruta", "room", "state route", "ste", "suite", "unit",
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestNewISO20022PostalAddress(t *testing.T) {
+	tests := []struct {
+		addr          address.Address
+		want          address.ISO20022PostalAddress
+		wantStructure address.ISO20022Structure
+		// wantLine1 is the first line after a round trip, if it differs.
+		wantLine1 string
+	}{
+		{
+			address.Address{Line1: "1098 Alta Ave", Locality: "Mountain View", Region: "CA", PostalCode: "94043", CountryCode: "US"},
+			address.ISO20022PostalAddress{StreetName: "Alta Ave", BuildingNumber: "1098", PostCode: "94043", TownName: "Mountain View", CountrySubDivision: "CA", Country: "US"},
+			address.ISO20022Structured,
+			"",
+		},
+		{
+			address.Address{Line1: "Calle Numa 55", Line2: "Piso 3", Locality: "Dos Hermanas", Region: "SE", PostalCode: "41089", CountryCode: "ES"},
+			address.ISO20022PostalAddress{StreetName: "Calle Numa", BuildingNumber: "55", PostCode: "41089", TownName: "Dos Hermanas", CountrySubDivision: "SE", Country: "ES", AddressLines: []string{"Piso 3"}},
+			address.ISO20022Hybrid,
+			"",
+		},
+		{
+			address.Address{Line1: "Via Roma, 12/A", Locality: "Milano", PostalCode: "20121", CountryCode: "IT"},
+			address.ISO20022PostalAddress{StreetName: "Via Roma", BuildingNumber: "12/A", PostCode: "20121", TownName: "Milano", Country: "IT"},
+			address.ISO20022Structured,
+			"Via Roma 12/A",
+		},
+		{
+			address.Address{Line1: "Rua Augusta, nº 120", Locality: "Lisboa", PostalCode: "1100-053", CountryCode: "PT"},
+			address.ISO20022PostalAddress{StreetName: "Rua Augusta", BuildingNumber: "120", PostCode: "1100-053", TownName: "Lisboa", Country: "PT"},
+			address.ISO20022Structured,
+			"Rua Augusta 120",
+		},
+		// Ambiguous building numbers.
+		{
+			address.Address{Line1: "Route 66", Locality: "Hackberry", Region: "AZ", PostalCode: "86411", CountryCode: "US"},
+			address.ISO20022PostalAddress{StreetName: "Route 66", PostCode: "86411", TownName: "Hackberry", CountrySubDivision: "AZ", Country: "US"},
+			address.ISO20022Structured,
+			"",
+		},
+		{
+			address.Address{Line1: "Unit 2050", Line2: "1098 Alta Ave", Locality: "Mountain View", Region: "CA", PostalCode: "94043", CountryCode: "US"},
+			address.ISO20022PostalAddress{StreetName: "Unit 2050", PostCode: "94043", TownName: "Mountain View", CountrySubDivision: "CA", Country: "US", AddressLines: []string{"1098 Alta Ave"}},
+			address.ISO20022Hybrid,
+			"",
+		},
+		{
+			address.Address{Line1: "Calle 5 10", Locality: "Cali", PostalCode: "760044", CountryCode: "CO"},
+			address.ISO20022PostalAddress{StreetName: "Calle 5 10", PostCode: "760044", TownName: "Cali", Country: "CO"},
+			address.ISO20022Structured,
+			"",
+		},
+		{
+			address.Address{Line1: "Calle 5 10", Locality: "San Juan", PostalCode: "00901", CountryCode: "PR"},
+			address.ISO20022PostalAddress{StreetName: "Calle 5 10", PostCode: "00901", TownName: "San Juan", Country: "PR"},
+			address.ISO20022Structured,
+			"",
+		},
+		{
+			// The building number is on the wrong side for the country.
+			address.Address{Line1: "Alta Ave 1098", Locality: "Mountain View", Region: "CA", PostalCode: "94043", CountryCode: "US"},
+			address.ISO20022PostalAddress{StreetName: "Alta Ave 1098", PostCode: "94043", TownName: "Mountain View", CountrySubDivision: "CA", Country: "US"},
+			address.ISO20022Structured,
+			"",
+		},
+		// PO boxes.
+		{
+			address.Address{Line1: "PO Box 123", Locality: "Mountain View", Region: "CA", PostalCode: "94043", CountryCode: "US"},
+			address.ISO20022PostalAddress{PostBox: "PO Box 123", PostCode: "94043", TownName: "Mountain View", CountrySubDivision: "CA", Country: "US"},
+			address.ISO20022Structured,
+			"",
+		},
+		{
+			address.Address{Line1: "Postfach 1234", Locality: "Berlin", PostalCode: "10001", CountryCode: "DE"},
+			address.ISO20022PostalAddress{PostBox: "Postfach 1234", PostCode: "10001", TownName: "Berlin", Country: "DE"},
+			address.ISO20022Structured,
+			"",
+		},
+		// No building number.
+		{
+			address.Address{Line1: "The Old Rectory", Sublocality: "Sandford", Locality: "Crediton", PostalCode: "EX17 4LW", CountryCode: "GB"},
+			address.ISO20022PostalAddress{StreetName: "The Old Rectory", PostCode: "EX17 4LW", TownName: "Crediton", TownLocationName: "Sandford", Country: "GB"},
+			address.ISO20022Structured,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr.CountryCode, func(t *testing.T) {
+			got := address.NewISO20022PostalAddress(tt.addr)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+			if got.Structure() != tt.wantStructure {
+				t.Errorf("got structure %v, want %v", got.Structure(), tt.wantStructure)
+			}
+			// Round trip.
+			wantAddr := tt.addr
+			if tt.wantLine1 != "" {
+				wantAddr.Line1 = tt.wantLine1
+			}
+			if back := got.Address(); back != wantAddr {
+				t.Errorf("got %#v, want %#v", back, wantAddr)
+			}
+		})
+	}
+}
+
+func TestISO20022PostalAddress_Address(t *testing.T) {
+	tests := []struct {
+		name string
+		p    address.ISO20022PostalAddress
+		want address.Address
+	}{
+		{
+			"structured with details",
+			address.ISO20022PostalAddress{StreetName: "Rue de la Loi", BuildingNumber: "16", Floor: "3", Room: "301", PostCode: "1000", TownName: "Bruxelles", Country: "be"},
+			address.Address{Line1: "Rue de la Loi 16", Line2: "3, 301", Locality: "Bruxelles", PostalCode: "1000", CountryCode: "BE"},
+		},
+		{
+			"region name",
+			address.ISO20022PostalAddress{StreetName: "Alta Ave", BuildingNumber: "1098", PostCode: "94043", TownName: "Mountain View", CountrySubDivision: "California", Country: "US"},
+			address.Address{Line1: "1098 Alta Ave", Locality: "Mountain View", Region: "CA", PostalCode: "94043", CountryCode: "US"},
+		},
+		{
+			"unstructured",
+			address.ISO20022PostalAddress{Country: "DE", AddressLines: []string{"Hauptstraße 5", "Hinterhaus", "10115 Berlin"}},
+			address.Address{Line1: "Hauptstraße 5", Line2: "Hinterhaus", Locality: "Berlin", PostalCode: "10115", CountryCode: "DE"},
+		},
+		{
+			"unstructured without postal code",
+			address.ISO20022PostalAddress{Country: "IE", AddressLines: []string{"Main Street", "Kilkenny"}},
+			address.Address{Line1: "Main Street", Locality: "Kilkenny", CountryCode: "IE"},
+		},
+		{
+			"extra lines",
+			address.ISO20022PostalAddress{StreetName: "Hauptstraße", BuildingNumber: "5", BuildingName: "Hinterhaus", TownName: "Berlin", Country: "DE", AddressLines: []string{"c/o Müller", "Abt. 4"}},
+			address.Address{Line1: "Hauptstraße 5", Line2: "Hinterhaus", Line3: "c/o Müller, Abt. 4", Locality: "Berlin", CountryCode: "DE"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.p.Address()
+			if got != tt.want {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestISO20022PostalAddress_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       address.ISO20022PostalAddress
+		wantErr string
+	}{
+		{
+			"valid",
+			address.ISO20022PostalAddress{StreetName: "Alta Ave", BuildingNumber: "1098", PostCode: "94043", TownName: "Mountain View", CountrySubDivision: "CA", Country: "US"},
+			"",
+		},
+		{
+			"town name too long",
+			address.ISO20022PostalAddress{TownName: "Llanfairpwllgwyngyllgogerychwyrndrobwllllantysiliogogogoch", Country: "GB"},
+			"invalid ISO 20022 postal address: TwnNm is longer than 35 characters",
+		},
+		{
+			"multiple errors",
+			address.ISO20022PostalAddress{BuildingNumber: "Building number 12345", PostBox: "Post Office Box 12345", TownName: "Berlin", Country: "Germany"},
+			`invalid ISO 20022 postal address: BldgNb is longer than 16 characters; PstBx is longer than 16 characters; Ctry "Germany" is not a two-letter country code`,
+		},
+		{
+			"too many hybrid lines",
+			address.ISO20022PostalAddress{TownName: "Berlin", Country: "DE", AddressLines: []string{"Hauptstraße 5", "Hinterhaus", "c/o Müller"}},
+			"invalid ISO 20022 postal address: more than 2 AdrLine elements",
+		},
+		{
+			"unstructured lines",
+			address.ISO20022PostalAddress{Country: "DE", AddressLines: []string{"Hauptstraße 5", "Hinterhaus", "c/o Müller", "10115 Berlin"}},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("got %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Structure doesn't check lengths.
+	p := address.ISO20022PostalAddress{TownName: "Llanfairpwllgwyngyllgogerychwyrndrobwllllantysiliogogogoch", Country: "GB"}
+	if p.Structure() != address.ISO20022Structured {
+		t.Errorf("got structure %v, want structured", p.Structure())
+	}
+}
+
+func TestISO20022PostalAddress_XML(t *testing.T) {
+	type party struct {
+		XMLName xml.Name                      `xml:"Cdtr"`
+		Name    string                        `xml:"Nm"`
+		Address address.ISO20022PostalAddress `xml:"PstlAdr"`
+	}
+	p := party{
+		Name:    "ACME GmbH",
+		Address: address.ISO20022PostalAddress{StreetName: "Hauptstraße", BuildingNumber: "5", PostCode: "10115", TownName: "Berlin", Country: "DE", AddressLines: []string{"Hinterhaus"}},
+	}
+	data, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<Cdtr><Nm>ACME GmbH</Nm><PstlAdr><StrtNm>Hauptstraße</StrtNm><BldgNb>5</BldgNb><PstCd>10115</PstCd><TwnNm>Berlin</TwnNm><Ctry>DE</Ctry><AdrLine>Hinterhaus</AdrLine></PstlAdr></Cdtr>"
+	if string(data) != want {
+		t.Errorf("got %v, want %v", string(data), want)
+	}
+	var got party
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Address, p.Address) {
+		t.Errorf("got %#v, want %#v", got.Address, p.Address)
+	}
+}
+
+func TestISO20022Structure(t *testing.T) {
+	var s address.ISO20022Structure
+	if err := s.UnmarshalText([]byte("hybrid")); err != nil || s != address.ISO20022Hybrid {
+		t.Errorf("got %v, %v, want hybrid", s, err)
+	}
+	if err := s.UnmarshalText([]byte("invalid")); err == nil {
+		t.Error("expected an error for an invalid structure.")
+	}
+	text, _ := address.ISO20022Unstructured.MarshalText()
+	if string(text) != "unstructured" {
+		t.Errorf("got %v, want unstructured", string(text))
+	}
+}
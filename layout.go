@@ -0,0 +1,102 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import "strings"
+
+// Layout represents a parsed address layout.
+//
+// Layouts are defined as strings such as "%1\n%2\n%3\n%L, %R %P",
+// where each line is separated by "\n" and each field is referenced
+// by a "%" token. Parsing them allows consumers (e.g. form builders)
+// to avoid reimplementing the tokenizer.
+type Layout struct {
+	Lines []LayoutLine `json:"lines"`
+}
+
+// LayoutLine represents a single line of an address layout.
+type LayoutLine struct {
+	// Fields are the fields on the line, in order.
+	Fields []Field `json:"fields"`
+	// Separators are the literals around the fields, with one more
+	// element than Fields. Separators[0] is the literal before the first
+	// field, Separators[i] is the literal between Fields[i-1] and Fields[i],
+	// and the last element is the literal after the last field.
+	Separators []string `json:"separators"`
+}
+
+// ParseLayout parses the given layout string.
+func ParseLayout(layout string) Layout {
+	if layout == "" {
+		return Layout{}
+	}
+	rawLines := strings.Split(layout, "\n")
+	l := Layout{
+		Lines: make([]LayoutLine, 0, len(rawLines)),
+	}
+	for _, rawLine := range rawLines {
+		line := LayoutLine{}
+		prev := 0
+		for i := 0; i+1 < len(rawLine); i++ {
+			if rawLine[i] != '%' {
+				continue
+			}
+			line.Separators = append(line.Separators, rawLine[prev:i])
+			line.Fields = append(line.Fields, Field(rawLine[i+1:i+2]))
+			prev = i + 2
+			i++
+		}
+		line.Separators = append(line.Separators, rawLine[prev:])
+		l.Lines = append(l.Lines, line)
+	}
+
+	return l
+}
+
+// Fields returns all fields in the layout, in order.
+func (l Layout) Fields() []Field {
+	var fields []Field
+	for _, line := range l.Lines {
+		fields = append(fields, line.Fields...)
+	}
+	return fields
+}
+
+// HasField returns whether the layout contains the given field.
+func (l Layout) HasField(field Field) bool {
+	for _, line := range l.Lines {
+		for _, f := range line.Fields {
+			if f == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String returns the string representation of l.
+func (l Layout) String() string {
+	b := strings.Builder{}
+	for i, line := range l.Lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		for j, field := range line.Fields {
+			b.WriteString(line.separator(j))
+			b.WriteByte('%')
+			b.WriteString(string(field))
+		}
+		b.WriteString(line.separator(len(line.Fields)))
+	}
+
+	return b.String()
+}
+
+// separator returns the separator at the given index, or an empty string if none found.
+func (l LayoutLine) separator(i int) string {
+	if i >= len(l.Separators) {
+		return ""
+	}
+	return l.Separators[i]
+}
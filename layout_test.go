@@ -0,0 +1,109 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   address.Layout
+	}{
+		// Empty layout.
+		{"", address.Layout{}},
+		// US layout.
+		{"%1\n%2\n%3\n%L, %R %P", address.Layout{
+			Lines: []address.LayoutLine{
+				{Fields: []address.Field{"1"}, Separators: []string{"", ""}},
+				{Fields: []address.Field{"2"}, Separators: []string{"", ""}},
+				{Fields: []address.Field{"3"}, Separators: []string{"", ""}},
+				{Fields: []address.Field{"L", "R", "P"}, Separators: []string{"", ", ", " ", ""}},
+			},
+		}},
+		// Japanese local layout, with a literal prefix.
+		{"〒%P\n%R%L\n%1\n%2\n%3", address.Layout{
+			Lines: []address.LayoutLine{
+				{Fields: []address.Field{"P"}, Separators: []string{"〒", ""}},
+				{Fields: []address.Field{"R", "L"}, Separators: []string{"", "", ""}},
+				{Fields: []address.Field{"1"}, Separators: []string{"", ""}},
+				{Fields: []address.Field{"2"}, Separators: []string{"", ""}},
+				{Fields: []address.Field{"3"}, Separators: []string{"", ""}},
+			},
+		}},
+		// Literal suffix.
+		{"%P- %L, %R", address.Layout{
+			Lines: []address.LayoutLine{
+				{Fields: []address.Field{"P", "L", "R"}, Separators: []string{"", "- ", ", ", ""}},
+			},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := address.ParseLayout(tt.layout)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if got.String() != tt.layout {
+				t.Errorf("got %q, want %q", got.String(), tt.layout)
+			}
+		})
+	}
+}
+
+func TestParseLayout_BuiltinFormats(t *testing.T) {
+	for countryCode, format := range address.GetFormats() {
+		for _, layout := range []string{format.Layout, format.LocalLayout} {
+			if got := address.ParseLayout(layout).String(); got != layout {
+				t.Errorf("%v: got %q, want %q", countryCode, got, layout)
+			}
+		}
+	}
+}
+
+func TestLayout_Fields(t *testing.T) {
+	layout := address.ParseLayout("%1\n%2\n%3\n%L, %R %P")
+	want := []address.Field{"1", "2", "3", "L", "R", "P"}
+	if got := layout.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !layout.HasField(address.FieldRegion) {
+		t.Error("expected the layout to contain FieldRegion.")
+	}
+	if layout.HasField(address.FieldSublocality) {
+		t.Error("expected the layout to not contain FieldSublocality.")
+	}
+}
+
+func TestLayout_MarshalJSON(t *testing.T) {
+	layout := address.ParseLayout("%1\n%L %P")
+	got, err := json.Marshal(layout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"lines":[{"fields":["1"],"separators":["",""]},{"fields":["L","P"],"separators":[""," ",""]}]}`
+	if string(got) != want {
+		t.Errorf("got %v, want %v", string(got), want)
+	}
+}
+
+func TestFormat_SelectParsedLayout(t *testing.T) {
+	format := address.GetFormat("JP")
+	got := format.SelectParsedLayout(address.NewLocale("ja"))
+	want := address.ParseLayout(format.LocalLayout)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	got = format.SelectParsedLayout(address.NewLocale("en"))
+	want = address.ParseLayout(format.Layout)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Normalize normalizes the given address.
+//
+// Whitespace is trimmed and collapsed, and the country code is uppercased.
+// Region names are converted to region IDs, and postal codes are
+// canonicalized (e.g. "sw1a1aa" to "SW1A 1AA"). Empty fields are filled in
+// from the address format defaults. Invalid values are left as-is,
+// to be reported by validation.
+func Normalize(addr Address) Address {
+	countryCode := strings.ToUpper(strings.TrimSpace(addr.CountryCode))
+	return normalize(addr, GetFormat(countryCode))
+}
+
+// Import prepares an address received from an external source (e.g. a file).
+//
+// The country code can also be an English country name, and the default
+// country code is used for addresses without a country. The address is
+// then normalized (see Normalize) and validated. An error is returned
+// if the country is unknown, in which case the address is empty.
+func Import(addr Address, defaultCountryCode string) (Address, []ValidationError, error) {
+	return importAddress(nil, addr, defaultCountryCode)
+}
+
+// importAddress prepares the given address, using the registry if not nil.
+func importAddress(r *Registry, addr Address, defaultCountryCode string) (Address, []ValidationError, error) {
+	country := strings.TrimSpace(addr.CountryCode)
+	if country == "" {
+		country = defaultCountryCode
+	}
+	countryCode := strings.ToUpper(country)
+	if r == nil || !r.Has(countryCode) {
+		var ok bool
+		if countryCode, ok = lookupCountryCode(country); !ok {
+			return Address{}, nil, fmt.Errorf("unknown country %q", country)
+		}
+	}
+	format := GetFormat(countryCode)
+	if r != nil {
+		format = r.Get(countryCode)
+	}
+	addr.CountryCode = countryCode
+	addr = normalize(addr, format)
+
+	return addr, format.Validate(addr), nil
+}
+
+// normalize normalizes the given address using the given format.
+func normalize(addr Address, format Format) Address {
+	addr = Address{
+		Line1:       collapseSpaces(addr.Line1),
+		Line2:       collapseSpaces(addr.Line2),
+		Line3:       collapseSpaces(addr.Line3),
+		Sublocality: collapseSpaces(addr.Sublocality),
+		Locality:    collapseSpaces(addr.Locality),
+		Region:      lookupRegion(format, collapseSpaces(addr.Region)),
+		PostalCode:  collapseSpaces(addr.PostalCode),
+		CountryCode: strings.ToUpper(strings.TrimSpace(addr.CountryCode)),
+	}
+	if addr.PostalCode != "" {
+		if postalCode, err := parsePostalCode(format, addr.CountryCode, addr.PostalCode); err == nil {
+			addr.PostalCode = postalCode.Value
+		}
+	}
+	for field, value := range format.Defaults {
+		switch field {
+		case FieldSublocality:
+			if addr.Sublocality == "" {
+				addr.Sublocality = value
+			}
+		case FieldLocality:
+			if addr.Locality == "" {
+				addr.Locality = value
+			}
+		case FieldRegion:
+			if addr.Region == "" {
+				addr.Region = value
+			}
+		case FieldPostalCode:
+			if addr.PostalCode == "" {
+				addr.PostalCode = value
+			}
+		}
+	}
+
+	return addr
+}
+
+// collapseSpaces trims the given value and collapses any inner whitespace.
+func collapseSpaces(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		addr address.Address
+		want address.Address
+	}{
+		{
+			address.Address{
+				Line1:       "  1098   Alta Ave ",
+				Locality:    "Mountain View",
+				Region:      "california",
+				PostalCode:  " 94043 1351",
+				CountryCode: "us",
+			},
+			address.Address{
+				Line1:       "1098 Alta Ave",
+				Locality:    "Mountain View",
+				Region:      "CA",
+				PostalCode:  "94043-1351",
+				CountryCode: "US",
+			},
+		},
+		{
+			address.Address{
+				Line1:       "10 Downing St",
+				Locality:    "London",
+				PostalCode:  "sw1a2aa",
+				CountryCode: "GB",
+			},
+			address.Address{
+				Line1:       "10 Downing St",
+				Locality:    "London",
+				PostalCode:  "SW1A 2AA",
+				CountryCode: "GB",
+			},
+		},
+		// Invalid values are left as-is.
+		{
+			address.Address{
+				Line1:       "1098 Alta Ave",
+				Region:      "Atlantis",
+				PostalCode:  "9404",
+				CountryCode: "US",
+			},
+			address.Address{
+				Line1:       "1098 Alta Ave",
+				Region:      "Atlantis",
+				PostalCode:  "9404",
+				CountryCode: "US",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := address.Normalize(tt.addr)
+			if got != tt.want {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize_Defaults(t *testing.T) {
+	for countryCode, format := range address.GetFormats() {
+		if len(format.Defaults) == 0 {
+			continue
+		}
+		addr := address.Normalize(address.Address{CountryCode: countryCode})
+		for field, value := range format.Defaults {
+			var got string
+			switch field {
+			case address.FieldLocality:
+				got = addr.Locality
+			case address.FieldRegion:
+				got = addr.Region
+			case address.FieldPostalCode:
+				got = addr.PostalCode
+			case address.FieldSublocality:
+				got = addr.Sublocality
+			default:
+				continue
+			}
+			if got != value {
+				t.Errorf("%v: got %q for field %v, want %q", countryCode, got, field, value)
+			}
+		}
+	}
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		addr        address.Address
+		want        address.Address
+		wantInvalid bool
+		wantErr     bool
+	}{
+		{
+			address.Address{Line1: "1098 Alta Ave", Locality: "Mountain View", Region: "california", PostalCode: "94043", CountryCode: "United States"},
+			address.Address{Line1: "1098 Alta Ave", Locality: "Mountain View", Region: "CA", PostalCode: "94043", CountryCode: "US"},
+			false,
+			false,
+		},
+		{
+			// Default country.
+			address.Address{Line1: "10 Downing St", Locality: "London", PostalCode: "sw1a2aa"},
+			address.Address{Line1: "10 Downing St", Locality: "London", PostalCode: "SW1A 2AA", CountryCode: "GB"},
+			false,
+			false,
+		},
+		{
+			address.Address{Line1: "Unit 2050", CountryCode: "us"},
+			address.Address{Line1: "Unit 2050", CountryCode: "US"},
+			true,
+			false,
+		},
+		{
+			address.Address{Line1: "Somewhere", CountryCode: "Atlantis"},
+			address.Address{},
+			false,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got, validationErrs, err := address.Import(tt.addr, "GB")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if (len(validationErrs) > 0) != tt.wantInvalid {
+				t.Errorf("got validation errors %v, want invalid %v", validationErrs, tt.wantInvalid)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PostalCode represents a parsed postal code.
+type PostalCode struct {
+	// Value is the canonical postal code (e.g. "94043-1234", "SW1A 1AA").
+	Value string
+	// Components are the named components of the postal code, in order.
+	// Empty for countries whose postal codes have no defined structure.
+	Components []PostalCodeComponent
+}
+
+// PostalCodeComponent represents a named postal code component.
+type PostalCodeComponent struct {
+	Name  string
+	Value string
+}
+
+// Component returns the value of the named component, or an empty string if none found.
+func (p PostalCode) Component(name string) string {
+	for _, c := range p.Components {
+		if c.Name == name {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+// postalCodeStructure describes the components of a postal code.
+//
+// The pattern must be anchored and contain a named group for each component.
+// The canonical value is built by joining non-empty components with the separator.
+type postalCodeStructure struct {
+	pattern   *regexp.Regexp
+	separator string
+}
+
+var (
+	zipStructure = postalCodeStructure{
+		pattern:   regexp.MustCompile(`^(?P<zip5>\d{5})(?:[ \-]?(?P<plus4>\d{4}))?$`),
+		separator: "-",
+	}
+	ukStructure = postalCodeStructure{
+		pattern:   regexp.MustCompile(`^(?P<outward>GIR|BFPO|[A-Z]{1,2}\d[\dA-Z]?) ?(?P<inward>0AA|\d[A-Z]{2}|\d{1,4})$`),
+		separator: " ",
+	}
+)
+
+var postalCodeStructures = map[string]postalCodeStructure{
+	"AS": zipStructure,
+	"BR": {
+		pattern:   regexp.MustCompile(`^(?P<prefix>\d{5})-?(?P<suffix>\d{3})$`),
+		separator: "-",
+	},
+	"CA": {
+		pattern:   regexp.MustCompile(`^(?P<fsa>[A-Z]\d[A-Z]) ?(?P<ldu>\d[A-Z]\d)$`),
+		separator: " ",
+	},
+	"FM": zipStructure,
+	"GB": ukStructure,
+	"GG": ukStructure,
+	"GU": zipStructure,
+	"IE": {
+		pattern:   regexp.MustCompile(`^(?P<routing_key>[\dA-Z]{3}) ?(?P<unique_identifier>[\dA-Z]{4})$`),
+		separator: " ",
+	},
+	"IM": ukStructure,
+	"JE": ukStructure,
+	"JP": {
+		pattern:   regexp.MustCompile(`^(?P<area>\d{3})-?(?P<district>\d{4})$`),
+		separator: "-",
+	},
+	"MH": zipStructure,
+	"MP": zipStructure,
+	"NL": {
+		pattern:   regexp.MustCompile(`^(?P<district>\d{4}) ?(?P<letters>[A-Z]{2})$`),
+		separator: " ",
+	},
+	"PL": {
+		pattern:   regexp.MustCompile(`^(?P<area>\d{2})-(?P<delivery>\d{3})$`),
+		separator: "-",
+	},
+	"PR": zipStructure,
+	"PW": zipStructure,
+	"SE": {
+		pattern:   regexp.MustCompile(`^(?P<area>\d{3}) ?(?P<delivery>\d{2})$`),
+		separator: " ",
+	},
+	"US": zipStructure,
+	"VI": zipStructure,
+}
+
+// ParsePostalCode parses the given postal code for the given country code.
+//
+// The value is trimmed, uppercased and validated using the country's
+// postal code pattern. For countries with a known postal code structure
+// (e.g. US ZIP5/plus4, UK outward/inward code, Canadian FSA/LDU),
+// the components are extracted and the value is canonicalized.
+func ParsePostalCode(countryCode, value string) (PostalCode, error) {
+	return parsePostalCode(GetFormat(countryCode), countryCode, value)
+}
+
+// parsePostalCode parses the given postal code using the given address format.
+func parsePostalCode(format Format, countryCode, value string) (PostalCode, error) {
+	value = strings.ToUpper(strings.Join(strings.Fields(value), " "))
+	if value == "" {
+		return PostalCode{}, fmt.Errorf("empty postal code")
+	}
+	if format.PostalCodePattern == "" || !format.CheckPostalCode(value) {
+		return PostalCode{}, fmt.Errorf("invalid postal code %q for country %q", value, countryCode)
+	}
+	structure, ok := postalCodeStructures[countryCode]
+	if !ok {
+		return PostalCode{Value: value}, nil
+	}
+	matches := structure.pattern.FindStringSubmatch(value)
+	if matches == nil {
+		return PostalCode{}, fmt.Errorf("invalid postal code %q for country %q", value, countryCode)
+	}
+	p := PostalCode{}
+	values := make([]string, 0, len(matches)-1)
+	for i, name := range structure.pattern.SubexpNames() {
+		if name == "" || matches[i] == "" {
+			continue
+		}
+		p.Components = append(p.Components, PostalCodeComponent{Name: name, Value: matches[i]})
+		values = append(values, matches[i])
+	}
+	p.Value = strings.Join(values, structure.separator)
+
+	return p, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestParsePostalCode(t *testing.T) {
+	tests := []struct {
+		countryCode string
+		value       string
+		want        address.PostalCode
+		wantErr     bool
+	}{
+		// Empty value.
+		{"US", "", address.PostalCode{}, true},
+		// Invalid value.
+		{"US", "ABCDE", address.PostalCode{}, true},
+		// Country with no postal codes.
+		{"AG", "AG123", address.PostalCode{}, true},
+		// ZIP5.
+		{"US", "94043", address.PostalCode{
+			Value: "94043",
+			Components: []address.PostalCodeComponent{
+				{Name: "zip5", Value: "94043"},
+			},
+		}, false},
+		// ZIP+4.
+		{"US", " 94043 1351", address.PostalCode{
+			Value: "94043-1351",
+			Components: []address.PostalCodeComponent{
+				{Name: "zip5", Value: "94043"},
+				{Name: "plus4", Value: "1351"},
+			},
+		}, false},
+		// US territory.
+		{"PR", "00901-1234", address.PostalCode{
+			Value: "00901-1234",
+			Components: []address.PostalCodeComponent{
+				{Name: "zip5", Value: "00901"},
+				{Name: "plus4", Value: "1234"},
+			},
+		}, false},
+		// UK postcode without a space.
+		{"GB", "sw1a1aa", address.PostalCode{
+			Value: "SW1A 1AA",
+			Components: []address.PostalCodeComponent{
+				{Name: "outward", Value: "SW1A"},
+				{Name: "inward", Value: "1AA"},
+			},
+		}, false},
+		// UK postcode with an invalid inward code.
+		{"GB", "SW1A 1AAB", address.PostalCode{}, true},
+		// Canadian postal code.
+		{"CA", "k1a 0b1", address.PostalCode{
+			Value: "K1A 0B1",
+			Components: []address.PostalCodeComponent{
+				{Name: "fsa", Value: "K1A"},
+				{Name: "ldu", Value: "0B1"},
+			},
+		}, false},
+		// Japanese postal code without a hyphen.
+		{"JP", "1000001", address.PostalCode{
+			Value: "100-0001",
+			Components: []address.PostalCodeComponent{
+				{Name: "area", Value: "100"},
+				{Name: "district", Value: "0001"},
+			},
+		}, false},
+		// Country with no known structure.
+		{"FR", "75002", address.PostalCode{Value: "75002"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got, err := address.ParsePostalCode(tt.countryCode, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostalCode_Component(t *testing.T) {
+	p, err := address.ParsePostalCode("CA", "H2X 1Y4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Component("fsa"); got != "H2X" {
+		t.Errorf("got %v, want H2X", got)
+	}
+	if got := p.Component("zip5"); got != "" {
+		t.Errorf(`got %v, want ""`, got)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// textFormatter formats addresses for String() and Format().
+var textFormatter = NewFormatter(Locale{Language: "en"})
+
+// String returns the address as a single line of text.
+//
+// For example: "1098 Alta Ave, Mountain View, CA 94043, United States".
+//
+// The full address is returned, which is personal data. Use the %r verb
+// (or Redact) when logging addresses or including them in errors.
+func (a Address) String() string {
+	return singleLine(textFormatter.FormatText(a))
+}
+
+// Format implements the fmt.Formatter interface.
+//
+// Supported verbs:
+//
+//	%s, %v  single line of text, see String()
+//	%+v     multiple lines of text, see Formatter.FormatText()
+//	%q      quoted single line of text
+//	%r      redacted single line of text, see Redact()
+//	%+r     redacted multiple lines of text
+//	%#v     Go syntax representation
+//
+// Only %r and %+r are safe for logs and error messages, all other verbs
+// print the full address.
+func (a Address) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprintf(f, "address.Address{Line1:%q, Line2:%q, Line3:%q, Sublocality:%q, Locality:%q, Region:%q, PostalCode:%q, CountryCode:%q}",
+				a.Line1, a.Line2, a.Line3, a.Sublocality, a.Locality, a.Region, a.PostalCode, a.CountryCode)
+			return
+		}
+		if f.Flag('+') {
+			f.Write([]byte(textFormatter.FormatText(a)))
+			return
+		}
+		f.Write([]byte(a.String()))
+	case 's':
+		f.Write([]byte(a.String()))
+	case 'q':
+		fmt.Fprintf(f, "%q", a.String())
+	case 'r':
+		text := textFormatter.FormatText(a.Redact())
+		if !f.Flag('+') {
+			text = singleLine(text)
+		}
+		f.Write([]byte(text))
+	default:
+		fmt.Fprintf(f, "%%!%c(address.Address=%s)", verb, a.String())
+	}
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (f Format) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 4)
+	if !f.Locale.IsEmpty() {
+		attrs = append(attrs, slog.String("locale", f.Locale.String()))
+	}
+	attrs = append(attrs, slog.String("layout", f.Layout))
+	if len(f.Required) > 0 {
+		required := make([]string, len(f.Required))
+		for i, field := range f.Required {
+			required[i] = string(field)
+		}
+		attrs = append(attrs, slog.String("required", strings.Join(required, ",")))
+	}
+	if f.PostalCodePattern != "" {
+		attrs = append(attrs, slog.String("postal_code_pattern", f.PostalCodePattern))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// singleLine joins the lines of the given text with commas.
+func singleLine(text string) string {
+	return strings.ReplaceAll(text, "\n", ", ")
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestAddress_String(t *testing.T) {
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Line2:       "Suite 100",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	want := "1098 Alta Ave, Suite 100, Mountain View, CA 94043, United States"
+	if got := addr.String(); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := (address.Address{}).String(); got != "" {
+		t.Errorf("got %v, want an empty string", got)
+	}
+}
+
+func TestAddress_Format(t *testing.T) {
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "1098 Alta Ave, Mountain View, CA 94043, United States"},
+		{"%s", "1098 Alta Ave, Mountain View, CA 94043, United States"},
+		{"%+v", "1098 Alta Ave\nMountain View, CA 94043\nUnited States"},
+		{"%q", `"1098 Alta Ave, Mountain View, CA 94043, United States"`},
+		{"%r", "***, ***, CA 940, United States"},
+		{"%+r", "***\n***, CA 940\nUnited States"},
+		{"%#v", `address.Address{Line1:"1098 Alta Ave", Line2:"", Line3:"", Sublocality:"", Locality:"Mountain View", Region:"CA", PostalCode:"94043", CountryCode:"US"}`},
+		{"%d", "%!d(address.Address=1098 Alta Ave, Mountain View, CA 94043, United States)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got := fmt.Sprintf(tt.format, addr)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Addresses embedded in errors.
+	err := fmt.Errorf("invalid address %r", addr)
+	want := "invalid address ***, ***, CA 940, United States"
+	if err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFormat_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("loaded", "format", address.GetFormat("US"))
+	got := strings.TrimSpace(buf.String())
+	want := `level=INFO msg=loaded format.layout="%1\n%2\n%3\n%L, %R %P" format.required=1,L,R,P format.postal_code_pattern="(\\d{5})(?:[ \\-](\\d{4}))?"`
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// redactedValue replaces masked values.
+const redactedValue = "***"
+
+// Redact returns a partially masked copy of the address, safe for logging.
+//
+// The address lines, sublocality and locality are masked, while the region
+// and country code are kept. The postal code is truncated to a prefix that
+// identifies a wide area, based on the country (e.g. the US ZIP3,
+// the UK outward code, the Canadian FSA).
+func (a Address) Redact() Address {
+	return Address{
+		Line1:       redact(a.Line1),
+		Line2:       redact(a.Line2),
+		Line3:       redact(a.Line3),
+		Sublocality: redact(a.Sublocality),
+		Locality:    redact(a.Locality),
+		Region:      a.Region,
+		PostalCode:  redactPostalCode(a.CountryCode, a.PostalCode),
+		CountryCode: a.CountryCode,
+	}
+}
+
+// LogValue implements the slog.LogValuer interface.
+//
+// The address is logged in its redacted form, see Redact().
+// Use Unredacted() to log the full address.
+func (a Address) LogValue() slog.Value {
+	return addressLogValue(a.Redact())
+}
+
+// Unredacted returns a slog.LogValuer that logs the full address.
+func (a Address) Unredacted() slog.LogValuer {
+	return unredactedAddress(a)
+}
+
+// unredactedAddress logs the full address.
+type unredactedAddress Address
+
+// LogValue implements the slog.LogValuer interface.
+func (a unredactedAddress) LogValue() slog.Value {
+	return addressLogValue(Address(a))
+}
+
+// addressLogValue returns a slog group value containing the non-empty address fields.
+func addressLogValue(a Address) slog.Value {
+	attrs := make([]slog.Attr, 0, 8)
+	for _, field := range []Field{FieldLine1, FieldLine2, FieldLine3, FieldSublocality, FieldLocality, FieldRegion, FieldPostalCode} {
+		if value := a.getField(field); value != "" {
+			attrs = append(attrs, slog.String(fieldName(field), value))
+		}
+	}
+	if a.CountryCode != "" {
+		attrs = append(attrs, slog.String("country", a.CountryCode))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// redact masks the given value, if non-empty.
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// redactPostalCode truncates the given postal code to a country-specific prefix.
+func redactPostalCode(countryCode, postalCode string) string {
+	postalCode = strings.ToUpper(strings.TrimSpace(postalCode))
+	if postalCode == "" {
+		return ""
+	}
+	switch countryCode {
+	case "GB", "GG", "IM", "JE":
+		// The outward code precedes the 3-character inward code.
+		postalCode = strings.ReplaceAll(postalCode, " ", "")
+		if len(postalCode) > 3 {
+			return postalCode[:len(postalCode)-3]
+		}
+		return ""
+	}
+	n, ok := postalCodePrefixLengths[countryCode]
+	if !ok {
+		n = 2
+	}
+	runes := []rune(postalCode)
+	if len(runes) <= n {
+		// Too short to be truncated safely.
+		return ""
+	}
+	return string(runes[:n])
+}
+
+// postalCodePrefixLengths are the lengths of redacted postal codes, keyed by country code.
+//
+// Countries not listed here use the first 2 characters.
+var postalCodePrefixLengths = map[string]int{
+	// ZIP3.
+	"AS": 3, "FM": 3, "GU": 3, "MH": 3, "MP": 3, "PR": 3, "PW": 3, "US": 3, "VI": 3,
+	// Forward sortation area.
+	"CA": 3,
+	// Routing key.
+	"IE": 3,
+	"JP": 3,
+	// Numeric part.
+	"NL": 4,
+}
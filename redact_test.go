@@ -0,0 +1,134 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestAddress_Redact(t *testing.T) {
+	tests := []struct {
+		addr address.Address
+		want address.Address
+	}{
+		{
+			address.Address{
+				Line1:       "1098 Alta Ave",
+				Line2:       "Suite 100",
+				Locality:    "Mountain View",
+				Region:      "CA",
+				PostalCode:  "94043-1351",
+				CountryCode: "US",
+			},
+			address.Address{
+				Line1:       "***",
+				Line2:       "***",
+				Locality:    "***",
+				Region:      "CA",
+				PostalCode:  "940",
+				CountryCode: "US",
+			},
+		},
+		{
+			address.Address{
+				Line1:       "10 Downing St",
+				Locality:    "London",
+				PostalCode:  "sw1a 2aa",
+				CountryCode: "GB",
+			},
+			address.Address{
+				Line1:       "***",
+				Locality:    "***",
+				PostalCode:  "SW1A",
+				CountryCode: "GB",
+			},
+		},
+		{
+			address.Address{
+				Line1:       "Rue de Rivoli 1",
+				Locality:    "Paris",
+				PostalCode:  "75001",
+				CountryCode: "FR",
+			},
+			address.Address{
+				Line1:       "***",
+				Locality:    "***",
+				PostalCode:  "75",
+				CountryCode: "FR",
+			},
+		},
+		{
+			address.Address{
+				Line1:       "Damrak 1",
+				Locality:    "Amsterdam",
+				PostalCode:  "1012 LG",
+				CountryCode: "NL",
+			},
+			address.Address{
+				Line1:       "***",
+				Locality:    "***",
+				PostalCode:  "1012",
+				CountryCode: "NL",
+			},
+		},
+		// Postal codes too short to be truncated are removed.
+		{
+			address.Address{
+				Line1:       "Calle 1",
+				PostalCode:  "12",
+				CountryCode: "ZZ",
+			},
+			address.Address{
+				Line1:       "***",
+				CountryCode: "ZZ",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tt.addr.Redact()
+			if got != tt.want {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddress_LogValue(t *testing.T) {
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("shipped", "address", addr)
+	got := strings.TrimSpace(buf.String())
+	want := `level=INFO msg=shipped address.line1=*** address.locality=*** address.region=CA address.postal_code=940 address.country=US`
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	buf.Reset()
+	logger.Info("shipped", "address", addr.Unredacted())
+	got = strings.TrimSpace(buf.String())
+	want = `level=INFO msg=shipped address.line1="1098 Alta Ave" address.locality="Mountain View" address.region=CA address.postal_code=94043 address.country=US`
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Registry represents a customizable set of address formats.
+//
+// A registry starts from the built-in formats, allowing callers to
+// override or extend them (e.g. to make Line2 required for a country,
+// or to add a missing territory). This allows different tenants to have
+// different rules without modifying the package-level data.
+//
+// A registry should be fully populated before use, since it is not
+// safe for concurrent modification.
+type Registry struct {
+	formats map[string]Format
+}
+
+// NewRegistry creates a new registry populated with the built-in formats.
+func NewRegistry() *Registry {
+	r := &Registry{
+		formats: make(map[string]Format, len(formats)),
+	}
+	for countryCode, format := range formats {
+		r.formats[countryCode] = format
+	}
+	return r
+}
+
+// Get returns the address format for the given country code.
+//
+// Falls back to the generic ZZ address format if none found.
+//
+// The returned format shares its slices and maps with the registry.
+// They should be replaced instead of modified before calling Set().
+func (r *Registry) Get(countryCode string) Format {
+	format, ok := r.formats[countryCode]
+	if !ok {
+		return r.formats["ZZ"]
+	}
+	return format
+}
+
+// GetAll returns all address formats, keyed by country code.
+//
+// The returned map is a copy, use Set() to modify the registry.
+func (r *Registry) GetAll() map[string]Format {
+	formats := make(map[string]Format, len(r.formats))
+	for countryCode, format := range r.formats {
+		formats[countryCode] = format
+	}
+	return formats
+}
+
+// Has returns whether an address format exists for the given country code.
+func (r *Registry) Has(countryCode string) bool {
+	_, ok := r.formats[countryCode]
+	return ok
+}
+
+// Set sets the address format for the given country code.
+//
+// Replaces any existing address format. The address format is validated
+// like in LoadJSON, and an error is returned if it is invalid, in which
+// case the registry is left unchanged.
+func (r *Registry) Set(countryCode string, format Format) error {
+	if err := checkFormat(format); err != nil {
+		return fmt.Errorf("Set: %v: %w", countryCode, err)
+	}
+	r.formats[countryCode] = format
+	return nil
+}
+
+// SetRegions sets the regions for the given country code.
+//
+// The local regions are optional, and only used when the address format
+// defines a locale.
+func (r *Registry) SetRegions(countryCode string, regions RegionMap, localRegions RegionMap) {
+	format := r.Get(countryCode)
+	format.Regions = regions
+	format.LocalRegions = localRegions
+	r.formats[countryCode] = format
+}
+
+// CheckCountryCode checks whether the given country code is valid.
+//
+// A country code is valid if it is known to the package, or if the
+// registry has an address format for it (e.g. a custom territory).
+// An empty country code is considered valid.
+func (r *Registry) CheckCountryCode(countryCode string) bool {
+	return CheckCountryCode(countryCode) || r.Has(countryCode)
+}
+
+// ParsePostalCode parses the given postal code for the given country code.
+//
+// See the package-level ParsePostalCode for details.
+func (r *Registry) ParsePostalCode(countryCode, value string) (PostalCode, error) {
+	return parsePostalCode(r.Get(countryCode), countryCode, value)
+}
+
+// Normalize normalizes the given address.
+//
+// See the package-level Normalize for details.
+func (r *Registry) Normalize(addr Address) Address {
+	countryCode := strings.ToUpper(strings.TrimSpace(addr.CountryCode))
+	return normalize(addr, r.Get(countryCode))
+}
+
+// Import prepares an address received from an external source (e.g. a file).
+//
+// See the package-level Import for details.
+func (r *Registry) Import(addr Address, defaultCountryCode string) (Address, []ValidationError, error) {
+	return importAddress(r, addr, defaultCountryCode)
+}
+
+// LoadJSON decodes address formats from JSON and merges them into the registry.
+//
+// The JSON must be an object of address formats keyed by country code,
+// in the same shape as Format (including locale, local_layout and local_regions).
+// Each decoded address format is merged over the existing one: only the
+// provided keys are replaced. Unknown country codes add new address formats.
+//
+// Layouts must only reference known fields, max lengths and scripts must
+// be valid, and postal code patterns must compile. If any address format
+// is invalid, the registry is left unchanged.
+func (r *Registry) LoadJSON(data []byte) error {
+	var aux map[string]json.RawMessage
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return fmt.Errorf("LoadJSON: %w", err)
+	}
+	loaded := make(map[string]Format, len(aux))
+	for countryCode, rawFormat := range aux {
+		format := Format{}
+		if existing, ok := r.formats[countryCode]; ok {
+			format = existing
+			// Copy the slices and maps to avoid modifying the existing data.
+			format.Required = append([]Field(nil), existing.Required...)
+			if existing.Defaults != nil {
+				format.Defaults = make(map[Field]string, len(existing.Defaults))
+				for field, value := range existing.Defaults {
+					format.Defaults[field] = value
+				}
+			}
+			if existing.MaxLengths != nil {
+				format.MaxLengths = make(map[Field]int, len(existing.MaxLengths))
+				for field, maxLength := range existing.MaxLengths {
+					format.MaxLengths[field] = maxLength
+				}
+			}
+			format.Scripts = append([]string(nil), existing.Scripts...)
+		}
+		if err := json.Unmarshal(rawFormat, &format); err != nil {
+			return fmt.Errorf("LoadJSON: %v: %w", countryCode, err)
+		}
+		if err := checkFormat(format); err != nil {
+			return fmt.Errorf("LoadJSON: %v: %w", countryCode, err)
+		}
+		loaded[countryCode] = format
+	}
+	for countryCode, format := range loaded {
+		r.formats[countryCode] = format
+	}
+
+	return nil
+}
+
+// checkFormat checks whether the given address format is valid.
+func checkFormat(f Format) error {
+	if f.Layout == "" {
+		return fmt.Errorf("missing layout")
+	}
+	for _, layout := range []string{f.Layout, f.LocalLayout} {
+		if err := checkLayout(layout); err != nil {
+			return err
+		}
+	}
+	for _, field := range f.Required {
+		if !isKnownField(field) {
+			return fmt.Errorf("invalid required field %q", field)
+		}
+	}
+	for field := range f.Defaults {
+		if !isKnownField(field) {
+			return fmt.Errorf("invalid default field %q", field)
+		}
+	}
+	for field, maxLength := range f.MaxLengths {
+		if !isKnownField(field) {
+			return fmt.Errorf("invalid max length field %q", field)
+		}
+		if maxLength < 0 {
+			return fmt.Errorf("invalid max length %d for field %q", maxLength, field)
+		}
+	}
+	for _, script := range f.Scripts {
+		if !isKnownScript(script) {
+			return fmt.Errorf("invalid script %q", script)
+		}
+	}
+	if _, err := regexp.Compile(f.PostalCodeValidationPattern()); err != nil {
+		return fmt.Errorf("invalid postal code pattern: %w", err)
+	}
+
+	return nil
+}
+
+// checkLayout checks whether the given layout only references known fields.
+//
+// An empty layout is considered valid.
+func checkLayout(layout string) error {
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' {
+			continue
+		}
+		if i+1 == len(layout) {
+			return fmt.Errorf("invalid layout %q: trailing %%", layout)
+		}
+		if field := Field(layout[i+1 : i+2]); !isKnownField(field) {
+			return fmt.Errorf("invalid layout %q: unknown field %q", layout, field)
+		}
+		i++
+	}
+
+	return nil
+}
@@ -0,0 +1,341 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestRegistry_Get(t *testing.T) {
+	r := address.NewRegistry()
+	got := r.Get("RS")
+	want := address.GetFormat("RS")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Non-existent format.
+	got = r.Get("IC")
+	want = address.GetFormat("ZZ")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if r.Has("IC") {
+		t.Error("got true, want false")
+	}
+}
+
+func TestRegistry_Set(t *testing.T) {
+	r := address.NewRegistry()
+	format := r.Get("US")
+	format.Required = []address.Field{address.FieldLine1, address.FieldLine2, address.FieldLocality}
+	if err := r.Set("US", format); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Get("US").IsRequired(address.FieldLine2) {
+		t.Error("expected FieldLine2 to be required.")
+	}
+	// Confirm that the built-in format was not modified.
+	if address.GetFormat("US").IsRequired(address.FieldLine2) {
+		t.Error("expected FieldLine2 to not be required in the built-in format.")
+	}
+
+	// New territory.
+	err := r.Set("IC", address.Format{
+		Layout:            "%1\n%2\n%3\n%P %L",
+		Required:          []address.Field{address.FieldLine1, address.FieldLocality},
+		PostalCodePattern: `35\d{3}|38\d{3}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Has("IC") {
+		t.Error("got false, want true")
+	}
+	if !r.Get("IC").CheckPostalCode("35001") {
+		t.Error("expected 35001 to be a valid postal code.")
+	}
+	if _, ok := r.GetAll()["IC"]; !ok {
+		t.Error(`address format "IC" not found.`)
+	}
+
+	// Invalid formats are rejected.
+	for _, format := range []address.Format{
+		{Layout: "%1\n%L", PostalCodePattern: "("},
+		{Layout: "%1\n%X"},
+		{},
+	} {
+		if err := r.Set("XX", format); err == nil {
+			t.Errorf("expected an error for %v.", format)
+		}
+	}
+	if r.Has("XX") {
+		t.Error("expected an invalid format to not be added.")
+	}
+
+	// GetAll returns a copy.
+	delete(r.GetAll(), "IC")
+	if !r.Has("IC") {
+		t.Error(`address format "IC" was removed via GetAll().`)
+	}
+}
+
+func TestRegistry_SetRegions(t *testing.T) {
+	r := address.NewRegistry()
+	regions := address.NewRegionMap("VO", "Vojvodina", "BG", "Belgrade")
+	r.SetRegions("RS", regions, address.RegionMap{})
+	format := r.Get("RS")
+	if !format.CheckRegion("VO") {
+		t.Error("expected VO to be a valid region.")
+	}
+	if format.CheckRegion("XX") {
+		t.Error("expected XX to be an invalid region.")
+	}
+	if address.GetFormat("RS").Regions.Len() != 0 {
+		t.Error("expected the built-in format to have no regions.")
+	}
+}
+
+func TestRegistry_CheckCountryCode(t *testing.T) {
+	r := address.NewRegistry()
+	if err := r.Set("XX", address.Format{Layout: "%1\n%L"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, countryCode := range []string{"", "FR", "XX"} {
+		if !r.CheckCountryCode(countryCode) {
+			t.Errorf("expected %q to be valid.", countryCode)
+		}
+	}
+	if r.CheckCountryCode("YY") {
+		t.Error(`expected "YY" to be invalid.`)
+	}
+}
+
+func TestRegistry_ParsePostalCode(t *testing.T) {
+	r := address.NewRegistry()
+	format := r.Get("AG")
+	format.PostalCodePattern = `AG\d{3}`
+	if err := r.Set("AG", format); err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.ParsePostalCode("AG", "ag123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Value != "AG123" {
+		t.Errorf("got %v, want AG123", got.Value)
+	}
+}
+
+func TestRegistry_Formatter(t *testing.T) {
+	r := address.NewRegistry()
+	format := r.Get("RS")
+	format.Layout = "%1\n%L %P"
+	if err := r.Set("RS", format); err != nil {
+		t.Fatal(err)
+	}
+
+	formatter := address.NewFormatter(address.NewLocale("en"))
+	formatter.Registry = r
+	formatter.NoCountry = true
+	addr := address.Address{
+		Line1:       "Bulevar Kralja Aleksandra 73",
+		Locality:    "Belgrade",
+		PostalCode:  "11000",
+		CountryCode: "RS",
+	}
+	wantLines := []string{
+		`<p class="address" translate="no">`,
+		`<span class="line1">Bulevar Kralja Aleksandra 73</span><br>`,
+		`<span class="locality">Belgrade</span> <span class="postal-code">11000</span>`,
+		`</p>`,
+	}
+	got := formatter.Format(addr)
+	want := strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestRegistry_FormatHandler(t *testing.T) {
+	r := address.NewRegistry()
+	if err := r.Set("XX", address.Format{Layout: "%1\n%L"}); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("GET", "/address-formats", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler := address.FormatHandler{Registry: r}
+	handler.ServeHTTP(rr, req)
+
+	var data map[string]testFormat
+	err = json.Unmarshal(rr.Body.Bytes(), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	format, ok := data["XX"]
+	if !ok {
+		t.Fatal(`address format "XX" not found.`)
+	}
+	if format.Layout != "%1\n%L" {
+		t.Errorf("got %q, want %q", format.Layout, "%1\n%L")
+	}
+
+	// Regions loaded at runtime are escaped.
+	err = r.LoadJSON([]byte(`{"XK": {"layout": "%1\n%L", "regions": {"A\"B": "Quote \"here\""}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("got HTTP %v, want HTTP %v", rr.Code, http.StatusOK)
+	}
+	data = nil
+	if err := json.Unmarshal(rr.Body.Bytes(), &data); err != nil {
+		t.Fatal(err)
+	}
+	if got := data["XK"].Regions[`A"B`]; got != `Quote "here"` {
+		t.Errorf("got %q, want %q", got, `Quote "here"`)
+	}
+	if _, ok := data["US"]; !ok {
+		t.Error(`address format "US" not found.`)
+	}
+}
+
+func TestRegistry_LoadJSON(t *testing.T) {
+	r := address.NewRegistry()
+	data := []byte(`{
+		"US": {"required": ["1", "2", "L", "R", "P"]},
+		"RS": {"regions": {"VO": "Vojvodina", "BG": "Belgrade"}},
+		"IC": {
+			"locale": "es",
+			"layout": "%1\n%2\n%3\n%P %L",
+			"local_layout": "%1\n%P %L",
+			"postal_code_pattern": "\\d{5}",
+			"region_type": "district",
+			"local_regions": {"TF": "Santa Cruz de Tenerife"}
+		}
+	}`)
+	if err := r.LoadJSON(data); err != nil {
+		t.Fatal(err)
+	}
+
+	// Merged format.
+	us := r.Get("US")
+	if !us.IsRequired(address.FieldLine2) {
+		t.Error("expected FieldLine2 to be required.")
+	}
+	if us.Layout != address.GetFormat("US").Layout {
+		t.Errorf("got %q, want %q", us.Layout, address.GetFormat("US").Layout)
+	}
+	if address.GetFormat("US").IsRequired(address.FieldLine2) {
+		t.Error("expected FieldLine2 to not be required in the built-in format.")
+	}
+	wantKeys := []string{"VO", "BG"}
+	if got := r.Get("RS").Regions.Keys(); !reflect.DeepEqual(got, wantKeys) {
+		t.Errorf("got %v, want %v", got, wantKeys)
+	}
+
+	// New format.
+	want := address.Format{
+		Locale:            address.Locale{Language: "es"},
+		Layout:            "%1\n%2\n%3\n%P %L",
+		LocalLayout:       "%1\n%P %L",
+		PostalCodePattern: `\d{5}`,
+		RegionType:        address.RegionTypeDistrict,
+		LocalRegions:      address.NewRegionMap("TF", "Santa Cruz de Tenerife"),
+	}
+	if got := r.Get("IC"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRegistry_LoadJSON_Constraints(t *testing.T) {
+	r := address.NewRegistry()
+	data := []byte(`{
+		"US": {"max_lengths": {"1": 35, "2": 35, "L": 30}, "scripts": ["Latn"]}
+	}`)
+	if err := r.LoadJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	us := r.Get("US")
+	if got := us.MaxLength(address.FieldLine1); got != 35 {
+		t.Errorf("got %v, want 35", got)
+	}
+	for _, ff := range us.FormFields(address.NewLocale("en")) {
+		if ff.MaxLength != us.MaxLength(ff.Field) {
+			t.Errorf("got %v for field %v, want %v", ff.MaxLength, ff.Field, us.MaxLength(ff.Field))
+		}
+	}
+	if got := address.GetFormat("US").MaxLength(address.FieldLine1); got != 64 {
+		t.Errorf("expected the built-in format to be unchanged, got %v", got)
+	}
+}
+
+func TestRegistry_LoadJSON_Invalid(t *testing.T) {
+	tests := []string{
+		// Invalid JSON.
+		`{"US": `,
+		// Unknown field in layout.
+		`{"US": {"layout": "%1\n%X"}}`,
+		// Trailing percent sign in layout.
+		`{"US": {"local_layout": "%1\n%"}}`,
+		// Unknown required field.
+		`{"US": {"required": ["1", "Q"]}}`,
+		// Invalid postal code pattern.
+		`{"US": {"postal_code_pattern": "(\\d{5}"}}`,
+		// Unknown max length field.
+		`{"US": {"max_lengths": {"Q": 10}}}`,
+		// Negative max length.
+		`{"US": {"max_lengths": {"1": -1}}}`,
+		// Unknown script.
+		`{"US": {"scripts": ["Klingon"]}}`,
+		// Invalid region type.
+		`{"US": {"region_type": "galaxy"}}`,
+		// New format without a layout.
+		`{"IC": {"postal_code_pattern": "\\d{5}"}}`,
+		// Valid and invalid format mixed.
+		`{"RS": {"layout": "%1\n%L"}, "US": {"layout": "%1\n%X"}}`,
+	}
+	for _, data := range tests {
+		t.Run("", func(t *testing.T) {
+			r := address.NewRegistry()
+			if err := r.LoadJSON([]byte(data)); err == nil {
+				t.Errorf("expected an error for %v", data)
+			}
+			// Confirm that the registry was left unchanged.
+			if got := r.Get("RS"); !reflect.DeepEqual(got, address.GetFormat("RS")) {
+				t.Errorf("got %v, want %v", got, address.GetFormat("RS"))
+			}
+		})
+	}
+}
+
+func TestRegistry_LoadJSON_BuiltinFormats(t *testing.T) {
+	// Confirm that all built-in formats can be encoded, decoded and validated.
+	data, err := json.Marshal(address.GetFormats())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := address.NewRegistry()
+	if err := r.LoadJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	for countryCode, want := range address.GetFormats() {
+		if got := r.Get(countryCode); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"html"
+	"io"
+	"strings"
+)
+
+// Renderer renders a formatted address.
+//
+// The Formatter walks the address layout and calls the renderer for each
+// part of the address, allowing the same layout rules to be reused for
+// different outputs (e.g. HTML, plain text, terminals, PDF layout boxes).
+// Lines and fields with no values are skipped, along with their separators.
+//
+// Renderers which don't produce text can ignore the given writer.
+type Renderer interface {
+	// BeginAddress is called before anything else is rendered.
+	BeginAddress(w io.StringWriter, addr Address)
+	// EndAddress is called after everything else is rendered.
+	EndAddress(w io.StringWriter, addr Address)
+	// BeginLine is called at the start of each line, with the line index.
+	BeginLine(w io.StringWriter, index int)
+	// EndLine is called at the end of each line, with the line index.
+	EndLine(w io.StringWriter, index int)
+	// Field is called for each field with a value.
+	// Region IDs are already replaced by region names, if needed.
+	Field(w io.StringWriter, field Field, value string)
+	// Separator is called for each literal between fields.
+	Separator(w io.StringWriter, separator string)
+	// Country is called for the country, which is always on its own line.
+	Country(w io.StringWriter, countryCode string, name string)
+}
+
+// TextRenderer renders addresses as plain text.
+//
+// Lines are separated by newlines.
+type TextRenderer struct{}
+
+// BeginAddress implements the Renderer interface.
+func (r *TextRenderer) BeginAddress(w io.StringWriter, addr Address) {}
+
+// EndAddress implements the Renderer interface.
+func (r *TextRenderer) EndAddress(w io.StringWriter, addr Address) {}
+
+// BeginLine implements the Renderer interface.
+func (r *TextRenderer) BeginLine(w io.StringWriter, index int) {
+	if index > 0 {
+		w.WriteString("\n")
+	}
+}
+
+// EndLine implements the Renderer interface.
+func (r *TextRenderer) EndLine(w io.StringWriter, index int) {}
+
+// Field implements the Renderer interface.
+func (r *TextRenderer) Field(w io.StringWriter, field Field, value string) {
+	w.WriteString(value)
+}
+
+// Separator implements the Renderer interface.
+func (r *TextRenderer) Separator(w io.StringWriter, separator string) {
+	w.WriteString(separator)
+}
+
+// Country implements the Renderer interface.
+func (r *TextRenderer) Country(w io.StringWriter, countryCode string, name string) {
+	w.WriteString(name)
+}
+
+// htmlRenderer renders addresses as HTML, using the formatter's settings.
+type htmlRenderer struct {
+	f *Formatter
+	// streetMeta indicates that the schema.org street address was written
+	// as a meta element, since it spans multiple fields.
+	streetMeta bool
+}
+
+// BeginAddress implements the Renderer interface.
+func (r *htmlRenderer) BeginAddress(w io.StringWriter, addr Address) {
+	w.WriteString(`<`)
+	w.WriteString(r.f.WrapperElement)
+	w.WriteString(` class="`)
+	w.WriteString(r.f.WrapperClass)
+	if r.f.StructuredData == StructuredDataMicroformats {
+		w.WriteString(" h-adr")
+	}
+	w.WriteString(`" translate="no"`)
+	switch r.f.StructuredData {
+	case StructuredDataMicrodata:
+		w.WriteString(` itemscope itemtype="https://schema.org/PostalAddress"`)
+	case StructuredDataRDFa:
+		w.WriteString(` vocab="https://schema.org/" typeof="PostalAddress"`)
+	}
+	w.WriteString(">\n")
+	r.writeStreetMeta(w, addr)
+}
+
+// writeStreetMeta writes the schema.org street address as a meta element,
+// if the address has multiple street values (e.g. line1 and line2).
+//
+// This ensures that consumers see a single streetAddress property,
+// instead of one per field.
+func (r *htmlRenderer) writeStreetMeta(w io.StringWriter, addr Address) {
+	r.streetMeta = false
+	if r.f.StructuredData != StructuredDataMicrodata && r.f.StructuredData != StructuredDataRDFa {
+		return
+	}
+	format := r.f.getFormat(addr.CountryCode)
+	locale := r.f.selectLocale(addr, format)
+	values := r.f.getValues(addr, format, locale)
+	street := streetValues(format.SelectParsedLayout(locale), &values)
+	if len(street) < 2 {
+		return
+	}
+	r.streetMeta = true
+	if r.f.StructuredData == StructuredDataMicrodata {
+		w.WriteString(`<meta itemprop="streetAddress" content="`)
+	} else {
+		w.WriteString(`<meta property="streetAddress" content="`)
+	}
+	w.WriteString(html.EscapeString(strings.Join(street, ", ")))
+	w.WriteString("\">\n")
+}
+
+// EndAddress implements the Renderer interface.
+func (r *htmlRenderer) EndAddress(w io.StringWriter, addr Address) {
+	w.WriteString("\n</")
+	w.WriteString(r.f.WrapperElement)
+	w.WriteString(">")
+}
+
+// BeginLine implements the Renderer interface.
+//
+// Lines are either wrapped in an element, or separated by <br>.
+func (r *htmlRenderer) BeginLine(w io.StringWriter, index int) {
+	if r.f.LineElement == "" {
+		if index > 0 {
+			w.WriteString("<br>\n")
+		}
+		return
+	}
+	if index > 0 {
+		w.WriteString("\n")
+	}
+	w.WriteString(`<`)
+	w.WriteString(r.f.LineElement)
+	if r.f.LineClass != "" {
+		w.WriteString(` class="`)
+		w.WriteString(html.EscapeString(r.f.LineClass))
+		w.WriteString(`"`)
+	}
+	w.WriteString(">")
+}
+
+// EndLine implements the Renderer interface.
+func (r *htmlRenderer) EndLine(w io.StringWriter, index int) {
+	if r.f.LineElement != "" {
+		w.WriteString(`</`)
+		w.WriteString(r.f.LineElement)
+		w.WriteString(`>`)
+	}
+}
+
+// Field implements the Renderer interface.
+func (r *htmlRenderer) Field(w io.StringWriter, field Field, value string) {
+	w.WriteString(`<span class="`)
+	r.f.writeClass(w, field)
+	w.WriteString(`"`)
+	property := schemaProperty(field)
+	if r.streetMeta && property == "streetAddress" {
+		property = ""
+	}
+	r.f.writeAttributes(w, field, property)
+	w.WriteString(`>`)
+	w.WriteString(html.EscapeString(value))
+	w.WriteString(`</span>`)
+}
+
+// Separator implements the Renderer interface.
+func (r *htmlRenderer) Separator(w io.StringWriter, separator string) {
+	w.WriteString(html.EscapeString(separator))
+}
+
+// Country implements the Renderer interface.
+func (r *htmlRenderer) Country(w io.StringWriter, countryCode string, name string) {
+	w.WriteString(`<span class="`)
+	r.f.writeCountryClass(w)
+	w.WriteString(`"`)
+	r.f.writeCountryAttributes(w, countryCode)
+	w.WriteString(`>`)
+	w.WriteString(html.EscapeString(name))
+	w.WriteString(`</span>`)
+}
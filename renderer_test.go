@@ -0,0 +1,154 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+// recordingRenderer records all renderer calls.
+type recordingRenderer struct {
+	calls []string
+}
+
+func (r *recordingRenderer) BeginAddress(w io.StringWriter, addr address.Address) {
+	r.calls = append(r.calls, "BeginAddress "+addr.CountryCode)
+}
+
+func (r *recordingRenderer) EndAddress(w io.StringWriter, addr address.Address) {
+	r.calls = append(r.calls, "EndAddress "+addr.CountryCode)
+}
+
+func (r *recordingRenderer) BeginLine(w io.StringWriter, index int) {
+	r.calls = append(r.calls, "BeginLine "+strconv.Itoa(index))
+}
+
+func (r *recordingRenderer) EndLine(w io.StringWriter, index int) {
+	r.calls = append(r.calls, "EndLine "+strconv.Itoa(index))
+}
+
+func (r *recordingRenderer) Field(w io.StringWriter, field address.Field, value string) {
+	r.calls = append(r.calls, "Field "+string(field)+" "+value)
+}
+
+func (r *recordingRenderer) Separator(w io.StringWriter, separator string) {
+	r.calls = append(r.calls, "Separator "+strconv.Quote(separator))
+}
+
+func (r *recordingRenderer) Country(w io.StringWriter, countryCode string, name string) {
+	r.calls = append(r.calls, "Country "+countryCode+" "+name)
+}
+
+// markdownRenderer renders addresses as Markdown, with bold localities.
+type markdownRenderer struct {
+	address.TextRenderer
+}
+
+func (r *markdownRenderer) BeginLine(w io.StringWriter, index int) {
+	if index > 0 {
+		w.WriteString("  \n")
+	}
+}
+
+func (r *markdownRenderer) Field(w io.StringWriter, field address.Field, value string) {
+	if field == address.FieldLocality {
+		value = "**" + value + "**"
+	}
+	w.WriteString(value)
+}
+
+func TestFormatter_Render(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	r := &recordingRenderer{}
+	sb := strings.Builder{}
+	formatter.Render(&sb, r, addr)
+	want := []string{
+		"BeginAddress US",
+		"BeginLine 0",
+		"Field 1 1098 Alta Ave",
+		"EndLine 0",
+		"BeginLine 1",
+		"Field L Mountain View",
+		`Separator ", "`,
+		"Field P 94043",
+		"EndLine 1",
+		"BeginLine 2",
+		"Country US United States",
+		"EndLine 2",
+		"EndAddress US",
+	}
+	if !reflect.DeepEqual(r.calls, want) {
+		t.Errorf("got %v, want %v", r.calls, want)
+	}
+
+	// Empty address.
+	r = &recordingRenderer{}
+	formatter.Render(&sb, r, address.Address{})
+	if len(r.calls) != 0 {
+		t.Errorf("got %v, want no calls", r.calls)
+	}
+
+	// Custom output.
+	sb.Reset()
+	formatter.Render(&sb, &markdownRenderer{}, addr)
+	got := sb.String()
+	wantText := "1098 Alta Ave  \n**Mountain View**, 94043  \nUnited States"
+	if got != wantText {
+		t.Errorf("got %q, want %q", got, wantText)
+	}
+}
+
+func TestFormatter_FormatText(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+
+	// Empty address.
+	if got := formatter.FormatText(address.Address{}); got != "" {
+		t.Errorf("got %q, want an empty string", got)
+	}
+
+	addr := address.Address{
+		Line1:       "Calle Numa <55>",
+		Locality:    "Dos Hermanas",
+		Region:      "SE",
+		PostalCode:  "41089",
+		CountryCode: "ES",
+	}
+	got := formatter.FormatText(addr)
+	want := "Calle Numa <55>\n41089 Dos Hermanas Sevilla\nSpain"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Local layout, with the country first.
+	formatter = address.NewFormatter(address.NewLocale("ja"))
+	formatter.CountryMapper = func(countryCode string, locale address.Locale) string {
+		return "日本"
+	}
+	addr = address.Address{
+		Line1:       "丸の内1-1",
+		Locality:    "千代田区",
+		Region:      "13",
+		PostalCode:  "100-0001",
+		CountryCode: "JP",
+	}
+	got = formatter.FormatText(addr)
+	want = "日本\n〒100-0001\n東京都千代田区\n丸の内1-1"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
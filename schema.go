@@ -0,0 +1,128 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// StructuredData represents a syntax for embedding structured data in HTML.
+type StructuredData uint8
+
+const (
+	// StructuredDataNone disables structured data.
+	StructuredDataNone StructuredData = iota
+	// StructuredDataMicrodata enables microdata (itemprop) attributes.
+	StructuredDataMicrodata
+	// StructuredDataRDFa enables RDFa (property) attributes.
+	StructuredDataRDFa
+	// StructuredDataMicroformats enables h-adr microformats2 classes.
+	StructuredDataMicroformats
+)
+
+// SchemaPostalAddress represents a schema.org PostalAddress.
+//
+// See https://schema.org/PostalAddress for details.
+type SchemaPostalAddress struct {
+	Context         string `json:"@context,omitempty"`
+	Type            string `json:"@type"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressRegion   string `json:"addressRegion,omitempty"`
+	PostalCode      string `json:"postalCode,omitempty"`
+	AddressCountry  string `json:"addressCountry,omitempty"`
+}
+
+// PostalAddress returns the schema.org PostalAddress for the given address.
+//
+// Only fields used by the address format are included.
+// The street address is made of the address lines, followed by the
+// sublocality, since schema.org has no equivalent property.
+// The region is represented the same way as in Format().
+func (f *Formatter) PostalAddress(addr Address) SchemaPostalAddress {
+	p := SchemaPostalAddress{
+		Type: "PostalAddress",
+	}
+	if addr.IsEmpty() {
+		return p
+	}
+	format := f.getFormat(addr.CountryCode)
+	locale := f.selectLocale(addr, format)
+	layout := format.SelectParsedLayout(locale)
+	values := f.getValues(addr, format, locale)
+	p.StreetAddress = strings.Join(streetValues(layout, &values), ", ")
+	if layout.HasField(FieldLocality) {
+		p.AddressLocality = values.get(FieldLocality)
+	}
+	if layout.HasField(FieldRegion) {
+		p.AddressRegion = values.get(FieldRegion)
+	}
+	if layout.HasField(FieldPostalCode) {
+		p.PostalCode = values.get(FieldPostalCode)
+	}
+	p.AddressCountry = addr.CountryCode
+
+	return p
+}
+
+// FormatJSONLD formats the given address as a schema.org PostalAddress in JSON-LD.
+//
+// The output is meant to be embedded in a <script type="application/ld+json"> element.
+func (f *Formatter) FormatJSONLD(addr Address) string {
+	if addr.IsEmpty() {
+		return ""
+	}
+	p := f.PostalAddress(addr)
+	p.Context = "https://schema.org"
+	// Escapes <, > and & to allow embedding in a script element.
+	jsonData, _ := json.Marshal(p)
+
+	return string(jsonData)
+}
+
+// streetValues returns the non-empty values that make up the schema.org street address.
+func streetValues(layout Layout, values *fieldValues) []string {
+	var street []string
+	for _, field := range []Field{FieldLine1, FieldLine2, FieldLine3, FieldSublocality} {
+		if values.get(field) != "" && layout.HasField(field) {
+			street = append(street, values.get(field))
+		}
+	}
+	return street
+}
+
+// schemaProperty returns the schema.org PostalAddress property for the given field.
+func schemaProperty(field Field) string {
+	switch field {
+	case FieldLine1, FieldLine2, FieldLine3, FieldSublocality:
+		return "streetAddress"
+	case FieldLocality:
+		return "addressLocality"
+	case FieldRegion:
+		return "addressRegion"
+	case FieldPostalCode:
+		return "postalCode"
+	}
+	return ""
+}
+
+// microformatClass returns the h-adr microformats2 class for the given field.
+//
+// The sublocality has no h-adr equivalent.
+func microformatClass(field Field) string {
+	switch field {
+	case FieldLine1:
+		return "p-street-address"
+	case FieldLine2, FieldLine3:
+		return "p-extended-address"
+	case FieldLocality:
+		return "p-locality"
+	case FieldRegion:
+		return "p-region"
+	case FieldPostalCode:
+		return "p-postal-code"
+	}
+	return ""
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestFormatter_PostalAddress(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+
+	// Empty address.
+	got := formatter.PostalAddress(address.Address{})
+	want := address.SchemaPostalAddress{Type: "PostalAddress"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Sublocality is not used by the US format.
+	addr := address.Address{
+		Line1:       "c/o The Westin Seattle",
+		Line2:       "1900 5th Avenue",
+		Sublocality: "Belltown",
+		Locality:    "Seattle",
+		Region:      "WA",
+		PostalCode:  "98101",
+		CountryCode: "US",
+	}
+	got = formatter.PostalAddress(addr)
+	want = address.SchemaPostalAddress{
+		Type:            "PostalAddress",
+		StreetAddress:   "c/o The Westin Seattle, 1900 5th Avenue",
+		AddressLocality: "Seattle",
+		AddressRegion:   "WA",
+		PostalCode:      "98101",
+		AddressCountry:  "US",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Sublocality is appended to the street address, region names are used.
+	addr = address.Address{
+		Line1:       "Xing Fu Zhong Lu",
+		Sublocality: "Xincheng Qu",
+		Locality:    "Xi'an Shi",
+		Region:      "SN",
+		PostalCode:  "710043",
+		CountryCode: "CN",
+	}
+	got = formatter.PostalAddress(addr)
+	want = address.SchemaPostalAddress{
+		Type:            "PostalAddress",
+		StreetAddress:   "Xing Fu Zhong Lu, Xincheng Qu",
+		AddressLocality: "Xi'an Shi",
+		AddressRegion:   "Shaanxi Sheng",
+		PostalCode:      "710043",
+		AddressCountry:  "CN",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFormatter_FormatJSONLD(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	if got := formatter.FormatJSONLD(address.Address{}); got != "" {
+		t.Errorf("got: %v, want an empty string", got)
+	}
+
+	addr := address.Address{
+		Line1:       "1098 Alta Ave</script>",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	got := formatter.FormatJSONLD(addr)
+	want := `{"@context":"https://schema.org","@type":"PostalAddress","streetAddress":"1098 Alta Ave\u003c/script\u003e","addressLocality":"Mountain View","addressRegion":"CA","postalCode":"94043","addressCountry":"US"}`
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestFormatter_FormatStructuredData(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+
+	formatter.StructuredData = address.StructuredDataMicrodata
+	wantLines := []string{
+		`<p class="address" translate="no" itemscope itemtype="https://schema.org/PostalAddress">`,
+		`<span class="line1" itemprop="streetAddress">1098 Alta Ave</span><br>`,
+		`<span class="locality" itemprop="addressLocality">Mountain View</span>, <span class="region" itemprop="addressRegion">CA</span> <span class="postal-code" itemprop="postalCode">94043</span><br>`,
+		`<span class="country" data-value="US" itemprop="addressCountry">United States</span>`,
+		`</p>`,
+	}
+	got := formatter.Format(addr)
+	want := strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	formatter.StructuredData = address.StructuredDataRDFa
+	wantLines = []string{
+		`<p class="address" translate="no" vocab="https://schema.org/" typeof="PostalAddress">`,
+		`<span class="line1" property="streetAddress">1098 Alta Ave</span><br>`,
+		`<span class="locality" property="addressLocality">Mountain View</span>, <span class="region" property="addressRegion">CA</span> <span class="postal-code" property="postalCode">94043</span><br>`,
+		`<span class="country" data-value="US" property="addressCountry" content="US">United States</span>`,
+		`</p>`,
+	}
+	got = formatter.Format(addr)
+	want = strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
+
+func TestFormatter_FormatStructuredData_MultipleLines(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Line2:       "Suite 200 & 201",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+
+	formatter.StructuredData = address.StructuredDataMicrodata
+	wantLines := []string{
+		`<p class="address" translate="no" itemscope itemtype="https://schema.org/PostalAddress">`,
+		`<meta itemprop="streetAddress" content="1098 Alta Ave, Suite 200 &amp; 201">`,
+		`<span class="line1">1098 Alta Ave</span><br>`,
+		`<span class="line2">Suite 200 &amp; 201</span><br>`,
+		`<span class="locality" itemprop="addressLocality">Mountain View</span>, <span class="region" itemprop="addressRegion">CA</span> <span class="postal-code" itemprop="postalCode">94043</span><br>`,
+		`<span class="country" data-value="US" itemprop="addressCountry">United States</span>`,
+		`</p>`,
+	}
+	got := formatter.Format(addr)
+	want := strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+	if n := strings.Count(got, "streetAddress"); n != 1 {
+		t.Errorf("got %v streetAddress properties, want 1", n)
+	}
+
+	formatter.StructuredData = address.StructuredDataRDFa
+	got = formatter.Format(addr)
+	if !strings.Contains(got, `<meta property="streetAddress" content="1098 Alta Ave, Suite 200 &amp; 201">`) {
+		t.Errorf("got:\n%v\nwant a streetAddress meta element", got)
+	}
+	if n := strings.Count(got, "streetAddress"); n != 1 {
+		t.Errorf("got %v streetAddress properties, want 1", n)
+	}
+}
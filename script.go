@@ -0,0 +1,120 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import "unicode"
+
+// scripts are the detected scripts, with their ISO 15924 codes.
+var scripts = []struct {
+	code  string
+	table *unicode.RangeTable
+}{
+	{"Latn", unicode.Latin},
+	{"Cyrl", unicode.Cyrillic},
+	{"Grek", unicode.Greek},
+	{"Hani", unicode.Han},
+	{"Hira", unicode.Hiragana},
+	{"Kana", unicode.Katakana},
+	{"Hang", unicode.Hangul},
+	{"Arab", unicode.Arabic},
+	{"Hebr", unicode.Hebrew},
+	{"Thai", unicode.Thai},
+	{"Armn", unicode.Armenian},
+	{"Geor", unicode.Georgian},
+	{"Deva", unicode.Devanagari},
+	{"Beng", unicode.Bengali},
+	{"Taml", unicode.Tamil},
+	{"Mymr", unicode.Myanmar},
+	{"Khmr", unicode.Khmer},
+	{"Laoo", unicode.Lao},
+	{"Ethi", unicode.Ethiopic},
+	{"Sinh", unicode.Sinhala},
+}
+
+// Script returns the ISO 15924 code of the script used by the address
+// (e.g. "Latn", "Cyrl", "Jpan").
+//
+// Only the address lines, sublocality and locality are considered, since
+// the region and postal code usually hold codes. The most common non-Latin
+// script wins, since local-script addresses often contain Latin characters
+// (e.g. building names). Japanese (Han and kana) is reported as "Jpan",
+// Korean (Hangul and Han) as "Kore". Returns an empty string if the
+// address has no letters.
+func (a Address) Script() string {
+	counts := make(map[string]int, 4)
+	for _, value := range []string{a.Line1, a.Line2, a.Line3, a.Sublocality, a.Locality} {
+		for _, r := range value {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			if script := scriptOf(r); script != "" {
+				counts[script]++
+			}
+		}
+	}
+	if counts["Hira"] > 0 || counts["Kana"] > 0 {
+		return "Jpan"
+	}
+	if counts["Hang"] > 0 {
+		return "Kore"
+	}
+	script := ""
+	max := 0
+	for _, s := range scripts[1:] {
+		if counts[s.code] > max {
+			script = s.code
+			max = counts[s.code]
+		}
+	}
+	if script == "" && counts["Latn"] > 0 {
+		script = "Latn"
+	}
+
+	return script
+}
+
+// scriptOf returns the ISO 15924 code of the script of the given letter.
+//
+// Returns an empty string if the script is unknown.
+func scriptOf(r rune) string {
+	if r < unicode.MaxASCII {
+		return "Latn"
+	}
+	for _, script := range scripts {
+		if unicode.Is(script.table, r) {
+			return script.code
+		}
+	}
+	return ""
+}
+
+// isKnownScript returns whether the given ISO 15924 code is known.
+func isKnownScript(code string) bool {
+	if code == "Jpan" || code == "Kore" {
+		return true
+	}
+	for _, script := range scripts {
+		if script.code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// scriptAllowed returns whether the given script is allowed by the given scripts.
+//
+// "Jpan" allows Han and kana, while "Kore" allows Hangul and Han.
+func scriptAllowed(script string, allowed []string) bool {
+	for _, a := range allowed {
+		switch {
+		case a == script:
+			return true
+		case a == "Jpan" && (script == "Hani" || script == "Hira" || script == "Kana"):
+			return true
+		case a == "Kore" && (script == "Hang" || script == "Hani"):
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestAddress_Script(t *testing.T) {
+	tests := []struct {
+		addr address.Address
+		want string
+	}{
+		{address.Address{CountryCode: "US"}, ""},
+		{address.Address{Line1: "123", PostalCode: "94043", CountryCode: "US"}, ""},
+		{address.Address{Line1: "1098 Alta Ave", Locality: "Mountain View", Region: "CA", CountryCode: "US"}, "Latn"},
+		{address.Address{Line1: "Cad 1", Locality: "Kadıköy", CountryCode: "TR"}, "Latn"},
+		{address.Address{Line1: "ул. Тверская, д. 7", Locality: "Москва", CountryCode: "RU"}, "Cyrl"},
+		{address.Address{Line1: "Οδός Ευριπίδου 10", Locality: "Αθήνα", CountryCode: "GR"}, "Grek"},
+		{address.Address{Line1: "幸福中路", Locality: "西安市", CountryCode: "CN"}, "Hani"},
+		{address.Address{Line1: "丸の内1-1", Locality: "千代田区", CountryCode: "JP"}, "Jpan"},
+		{address.Address{Line1: "세종대로 209", Locality: "종로구", CountryCode: "KR"}, "Kore"},
+		// Latin building names don't change the script.
+		{address.Address{Line1: "1-1 Marunouchi Building", Line2: "丸の内", CountryCode: "JP"}, "Jpan"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := tt.addr.Script()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatDetectScript(t *testing.T) {
+	locale := address.NewLocale("ja")
+	formatter := address.NewFormatter(locale)
+	formatter.DetectScript = true
+	formatter.NoCountry = true
+
+	// Latin addresses use the Latin layout, even for "ja" users.
+	addr := address.Address{
+		Line1:       "1-1 Marunouchi",
+		Locality:    "Chiyoda",
+		Region:      "13",
+		PostalCode:  "100-0001",
+		CountryCode: "JP",
+	}
+	got := formatter.FormatText(addr)
+	want := strings.Join([]string{"1-1 Marunouchi", "Chiyoda, Tokyo", "100-0001"}, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	// Local addresses use the local layout, even for "en" users.
+	formatter = address.NewFormatter(address.NewLocale("en"))
+	formatter.DetectScript = true
+	formatter.NoCountry = true
+	addr = address.Address{
+		Line1:       "丸の内1-1",
+		Locality:    "千代田区",
+		Region:      "13",
+		PostalCode:  "100-0001",
+		CountryCode: "JP",
+	}
+	got = formatter.FormatText(addr)
+	want = strings.Join([]string{"〒100-0001", "東京都千代田区", "丸の内1-1"}, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+
+	// Addresses without letters use the locale.
+	addr = address.Address{
+		Line1:       "1-1",
+		Region:      "13",
+		PostalCode:  "100-0001",
+		CountryCode: "JP",
+	}
+	got = formatter.FormatText(addr)
+	want = strings.Join([]string{"1-1", "Tokyo", "100-0001"}, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
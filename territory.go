@@ -0,0 +1,112 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+// Territory represents a territory that depends on another country.
+//
+// Territories have their own country codes and address formats
+// (e.g. Puerto Rico), but are often handled as part of their parent
+// country for shipping and customs purposes.
+type Territory struct {
+	// CountryCode is the country code of the territory (e.g. "PR").
+	CountryCode string `json:"country"`
+	// ParentCountryCode is the country code of the parent country (e.g. "US").
+	ParentCountryCode string `json:"parent_country"`
+	// PostalOperator is the name of the postal operator serving the territory.
+	PostalOperator string `json:"postal_operator,omitempty"`
+	// DomesticMail indicates whether mail to and from the parent country
+	// is handled as domestic mail.
+	DomesticMail bool `json:"domestic_mail"`
+	// ParentCustoms indicates whether the territory is inside the
+	// customs territory of the parent country.
+	ParentCustoms bool `json:"parent_customs"`
+}
+
+// GetTerritory returns the territory for the given country code.
+//
+// Returns false if the country code does not belong to a known territory.
+func GetTerritory(countryCode string) (Territory, bool) {
+	t, ok := territories[countryCode]
+	if ok {
+		t.CountryCode = countryCode
+	}
+	return t, ok
+}
+
+// IsDomestic returns whether mail between the given countries is domestic.
+//
+// For example, mail from the US to Puerto Rico is domestic, since USPS
+// serves both. Mail between two territories of the same parent country
+// (e.g. Puerto Rico and Guam) is also domestic.
+func IsDomestic(originCountryCode, destinationCountryCode string) bool {
+	return postalCountryCode(originCountryCode) == postalCountryCode(destinationCountryCode)
+}
+
+// IsSameCustomsTerritory returns whether the given countries share a customs territory.
+//
+// Only territories and their parent countries are considered,
+// customs unions between countries are not.
+func IsSameCustomsTerritory(originCountryCode, destinationCountryCode string) bool {
+	return customsCountryCode(originCountryCode) == customsCountryCode(destinationCountryCode)
+}
+
+// postalCountryCode returns the country code whose domestic mail covers the given country.
+func postalCountryCode(countryCode string) string {
+	if t, ok := territories[countryCode]; ok && t.DomesticMail {
+		return t.ParentCountryCode
+	}
+	return countryCode
+}
+
+// customsCountryCode returns the country code whose customs territory covers the given country.
+func customsCountryCode(countryCode string) string {
+	if t, ok := territories[countryCode]; ok && t.ParentCustoms {
+		return t.ParentCountryCode
+	}
+	return countryCode
+}
+
+// territories are the known territories, keyed by country code.
+var territories = map[string]Territory{
+	// United States.
+	"AS": {ParentCountryCode: "US", PostalOperator: "USPS", DomesticMail: true},
+	"GU": {ParentCountryCode: "US", PostalOperator: "USPS", DomesticMail: true},
+	"MP": {ParentCountryCode: "US", PostalOperator: "USPS", DomesticMail: true},
+	"PR": {ParentCountryCode: "US", PostalOperator: "USPS", DomesticMail: true, ParentCustoms: true},
+	"VI": {ParentCountryCode: "US", PostalOperator: "USPS", DomesticMail: true},
+	// Finland.
+	"AX": {ParentCountryCode: "FI", PostalOperator: "Åland Post", DomesticMail: true, ParentCustoms: true},
+	// Spain.
+	"EA": {ParentCountryCode: "ES", PostalOperator: "Correos", DomesticMail: true},
+	"IC": {ParentCountryCode: "ES", PostalOperator: "Correos", DomesticMail: true, ParentCustoms: true},
+	// United Kingdom.
+	"GG": {ParentCountryCode: "GB", PostalOperator: "Guernsey Post", DomesticMail: true, ParentCustoms: true},
+	"GI": {ParentCountryCode: "GB", PostalOperator: "Royal Gibraltar Post Office"},
+	"IM": {ParentCountryCode: "GB", PostalOperator: "Isle of Man Post Office", DomesticMail: true, ParentCustoms: true},
+	"JE": {ParentCountryCode: "GB", PostalOperator: "Jersey Post", DomesticMail: true, ParentCustoms: true},
+	// France.
+	"BL": {ParentCountryCode: "FR", PostalOperator: "La Poste", DomesticMail: true},
+	"GF": {ParentCountryCode: "FR", PostalOperator: "La Poste", DomesticMail: true, ParentCustoms: true},
+	"GP": {ParentCountryCode: "FR", PostalOperator: "La Poste", DomesticMail: true, ParentCustoms: true},
+	"MF": {ParentCountryCode: "FR", PostalOperator: "La Poste", DomesticMail: true, ParentCustoms: true},
+	"MQ": {ParentCountryCode: "FR", PostalOperator: "La Poste", DomesticMail: true, ParentCustoms: true},
+	"NC": {ParentCountryCode: "FR", PostalOperator: "OPT-NC"},
+	"PF": {ParentCountryCode: "FR", PostalOperator: "Fare Rata"},
+	"PM": {ParentCountryCode: "FR", PostalOperator: "La Poste", DomesticMail: true},
+	"RE": {ParentCountryCode: "FR", PostalOperator: "La Poste", DomesticMail: true, ParentCustoms: true},
+	"YT": {ParentCountryCode: "FR", PostalOperator: "La Poste", DomesticMail: true, ParentCustoms: true},
+	// Denmark.
+	"FO": {ParentCountryCode: "DK", PostalOperator: "Posta"},
+	"GL": {ParentCountryCode: "DK", PostalOperator: "Tusass"},
+	// Netherlands.
+	"AW": {ParentCountryCode: "NL"},
+	"BQ": {ParentCountryCode: "NL"},
+	"CW": {ParentCountryCode: "NL"},
+	"SX": {ParentCountryCode: "NL"},
+	// Norway.
+	"SJ": {ParentCountryCode: "NO", PostalOperator: "Posten Norge", DomesticMail: true},
+	// China.
+	"HK": {ParentCountryCode: "CN", PostalOperator: "Hongkong Post"},
+	"MO": {ParentCountryCode: "CN", PostalOperator: "Macau Post"},
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestGetTerritory(t *testing.T) {
+	territory, ok := address.GetTerritory("PR")
+	if !ok {
+		t.Fatal("expected PR to be a territory.")
+	}
+	want := address.Territory{
+		CountryCode:       "PR",
+		ParentCountryCode: "US",
+		PostalOperator:    "USPS",
+		DomesticMail:      true,
+		ParentCustoms:     true,
+	}
+	if territory != want {
+		t.Errorf("got %v, want %v", territory, want)
+	}
+
+	if _, ok := address.GetTerritory("US"); ok {
+		t.Error("expected US to not be a territory.")
+	}
+}
+
+func TestGetTerritory_ValidCountryCodes(t *testing.T) {
+	// Confirm that all parent countries are known, and not territories themselves.
+	for _, countryCode := range address.GetCountryCodes() {
+		territory, ok := address.GetTerritory(countryCode)
+		if !ok {
+			continue
+		}
+		if !address.CheckCountryCode(territory.ParentCountryCode) {
+			t.Errorf("%v: invalid parent country code %v", countryCode, territory.ParentCountryCode)
+		}
+		if _, ok := address.GetTerritory(territory.ParentCountryCode); ok {
+			t.Errorf("%v: parent country %v is a territory", countryCode, territory.ParentCountryCode)
+		}
+	}
+}
+
+func TestIsDomestic(t *testing.T) {
+	tests := []struct {
+		origin      string
+		destination string
+		want        bool
+	}{
+		{"US", "US", true},
+		{"US", "CA", false},
+		{"US", "PR", true},
+		{"PR", "US", true},
+		{"PR", "GU", true},
+		{"FI", "AX", true},
+		{"GB", "JE", true},
+		{"GB", "GI", false},
+		{"DK", "GL", false},
+		{"ES", "PR", false},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := address.IsDomestic(tt.origin, tt.destination)
+			if got != tt.want {
+				t.Errorf("%v to %v: got %v, want %v", tt.origin, tt.destination, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSameCustomsTerritory(t *testing.T) {
+	tests := []struct {
+		origin      string
+		destination string
+		want        bool
+	}{
+		{"US", "US", true},
+		{"US", "PR", true},
+		{"US", "VI", false},
+		{"US", "GU", false},
+		{"ES", "IC", true},
+		{"ES", "EA", false},
+		{"GB", "JE", true},
+		{"JE", "GG", true},
+		{"CN", "HK", false},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := address.IsSameCustomsTerritory(tt.origin, tt.destination)
+			if got != tt.want {
+				t.Errorf("%v to %v: got %v, want %v", tt.origin, tt.destination, got, tt.want)
+			}
+		})
+	}
+}
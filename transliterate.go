@@ -0,0 +1,296 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Transliterate converts the given address to the Latin script.
+//
+// Region names are converted to region IDs, allowing the formatter to show
+// the Latin region name. Other fields are romanized using per-script tables:
+// Cyrillic (BGN/PCGN without apostrophes, or the national standard for
+// Ukrainian addresses), Greek (ELOT 743) and Japanese kana (simplified
+// Hepburn, without long vowels). Fullwidth characters are converted to
+// their ASCII form. Characters without a romanization table
+// (e.g. Han ideographs) are left unchanged.
+//
+// Use Formatter.Transliterate to format the result using the Latin layout.
+func Transliterate(addr Address) Address {
+	return transliterate(addr, GetFormat(addr.CountryCode))
+}
+
+// transliterate converts the given address to the Latin script, using the given format.
+func transliterate(addr Address, format Format) Address {
+	language := format.Locale.Language
+	addr.Line1 = transliterateString(addr.Line1, language)
+	addr.Line2 = transliterateString(addr.Line2, language)
+	addr.Line3 = transliterateString(addr.Line3, language)
+	addr.Sublocality = transliterateString(addr.Sublocality, language)
+	addr.Locality = transliterateString(addr.Locality, language)
+	addr.PostalCode = transliterateString(addr.PostalCode, language)
+	if region := lookupRegion(format, addr.Region); format.Regions.HasKey(region) {
+		addr.Region = region
+	} else {
+		// Regions without a local name (e.g. Russian) can be matched
+		// once their name is transliterated.
+		addr.Region = lookupRegion(format, transliterateString(addr.Region, language))
+	}
+
+	return addr
+}
+
+// transliterateString converts the given string to the Latin script.
+//
+// The language is used to select language-specific rules, if any.
+func transliterateString(s string, language string) string {
+	if isASCII(s) {
+		return s
+	}
+	runes := []rune(s)
+	sb := strings.Builder{}
+	sb.Grow(len(s))
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		var latin string
+		n := 1
+		switch {
+		case r >= 0x0400 && r <= 0x04FF:
+			latin = transliterateCyrillic(runes, i, language)
+		case r >= 0x0370 && r <= 0x03FF:
+			latin, n = transliterateGreek(runes[i:])
+		case isKana(r):
+			latin, n = transliterateKana(runes[i:])
+			if i > 0 && isKana(runes[i-1]) && toHiragana(r) == 'う' && (strings.HasSuffix(sb.String(), "o") || strings.HasSuffix(sb.String(), "u")) {
+				// Long vowels are not marked (e.g. "Tokyo" instead of "Toukyou").
+				latin = ""
+			}
+			if i == 0 || !isKana(runes[i-1]) || runes[i-1] == '・' {
+				// Capitalize each word.
+				latin = capitalize(latin)
+			}
+		case r >= 0xFF01 && r <= 0xFF5E:
+			// Fullwidth ASCII.
+			latin = string(r - 0xFF01 + '!')
+		case r == 0x3000:
+			// Ideographic space.
+			latin = " "
+		case r == '、':
+			latin = ", "
+		default:
+			sb.WriteRune(r)
+			i++
+			continue
+		}
+		if unicode.IsUpper(r) {
+			// Uppercase the entire transliteration for words in uppercase.
+			if (i+n < len(runes) && unicode.IsUpper(runes[i+n])) || (i > 0 && unicode.IsUpper(runes[i-1])) {
+				latin = strings.ToUpper(latin)
+			} else {
+				latin = capitalize(latin)
+			}
+		}
+		sb.WriteString(latin)
+		i += n
+	}
+
+	return sb.String()
+}
+
+// transliterateCyrillic transliterates the Cyrillic letter at the given index.
+func transliterateCyrillic(runes []rune, i int, language string) string {
+	r := unicode.ToLower(runes[i])
+	if language == "uk" {
+		if i == 0 || !unicode.IsLetter(runes[i-1]) {
+			if latin, ok := ukrainianInitial[r]; ok {
+				return latin
+			}
+		}
+		if latin, ok := ukrainian[r]; ok {
+			return latin
+		}
+	}
+	return cyrillic[r]
+}
+
+// transliterateGreek transliterates the Greek letter at the start of the given runes.
+//
+// Returns the transliteration and the number of consumed runes.
+func transliterateGreek(runes []rune) (string, int) {
+	r := unicode.ToLower(removeGreekTonos(runes[0]))
+	if len(runes) > 1 {
+		next := unicode.ToLower(removeGreekTonos(runes[1]))
+		switch {
+		case r == 'ο' && next == 'υ':
+			return "ou", 2
+		case (r == 'α' || r == 'ε' || r == 'η') && next == 'υ':
+			// "υ" is pronounced "f" before voiceless consonants, "v" otherwise.
+			v := "v"
+			if len(runes) > 2 && strings.ContainsRune("θκξπσςτφχψ", unicode.ToLower(runes[2])) {
+				v = "f"
+			}
+			return greek[r] + v, 2
+		case r == 'γ' && (next == 'γ' || next == 'ξ' || next == 'χ'):
+			return "n" + greek[next], 2
+		}
+	}
+	return greek[r], 1
+}
+
+// removeGreekTonos returns the given Greek letter without its accent.
+func removeGreekTonos(r rune) rune {
+	if base, ok := greekTonos[r]; ok {
+		return base
+	}
+	return r
+}
+
+// transliterateKana transliterates the kana at the start of the given runes.
+//
+// Returns the transliteration and the number of consumed runes.
+func transliterateKana(runes []rune) (string, int) {
+	r := toHiragana(runes[0])
+	switch r {
+	case 'っ':
+		// The sokuon doubles the following consonant.
+		if len(runes) > 1 && isKana(runes[1]) {
+			next, n := transliterateKana(runes[1:])
+			if strings.HasPrefix(next, "ch") {
+				return "t" + next, n + 1
+			}
+			if next != "" && !strings.ContainsRune("aiueon", rune(next[0])) {
+				return next[:1] + next, n + 1
+			}
+			return next, n + 1
+		}
+		return "", 1
+	case 'ー':
+		// The long vowel mark is omitted, as is common in addresses.
+		return "", 1
+	}
+	if len(runes) > 1 {
+		if latin, ok := kana[string([]rune{r, toHiragana(runes[1])})]; ok {
+			return latin, 2
+		}
+	}
+	return kana[string(r)], 1
+}
+
+// isKana returns whether the given rune is a hiragana or katakana character.
+func isKana(r rune) bool {
+	return (r >= 0x3041 && r <= 0x3096) || (r >= 0x30A1 && r <= 0x30FC)
+}
+
+// toHiragana converts the given katakana character to hiragana.
+func toHiragana(r rune) rune {
+	if r >= 0x30A1 && r <= 0x30F6 {
+		return r - 0x60
+	}
+	return r
+}
+
+// capitalize uppercases the first letter of the given string.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// isASCII returns whether the given string contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// cyrillic maps lowercase Cyrillic letters to Latin.
+//
+// Based on BGN/PCGN for Russian, extended with Ukrainian,
+// Belarusian and Serbian/Macedonian letters.
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	// Ukrainian and Belarusian.
+	'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "w",
+	// Serbian and Macedonian.
+	'ђ': "đ", 'ј': "j", 'љ': "lj", 'њ': "nj", 'ћ': "ć", 'џ': "dž",
+	'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+}
+
+// ukrainian maps lowercase Ukrainian letters to Latin, when they differ
+// from the cyrillic map.
+//
+// Based on the Ukrainian national standard (2010).
+var ukrainian = map[rune]string{
+	'г': "h", 'и': "y", 'й': "i", 'є': "ie", 'ї': "i", 'ю': "iu", 'я': "ia",
+}
+
+// ukrainianInitial maps lowercase Ukrainian letters to Latin, at the start of a word.
+var ukrainianInitial = map[rune]string{
+	'й': "y", 'є': "ye", 'ї': "yi", 'ю': "yu", 'я': "ya",
+}
+
+// greek maps lowercase Greek letters to Latin.
+var greek = map[rune]string{
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i",
+	'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x",
+	'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y",
+	'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// greekTonos maps accented Greek letters to their base letter.
+var greekTonos = map[rune]rune{
+	'ά': 'α', 'έ': 'ε', 'ή': 'η', 'ί': 'ι', 'ό': 'ο', 'ύ': 'υ', 'ώ': 'ω',
+	'ϊ': 'ι', 'ϋ': 'υ', 'ΐ': 'ι', 'ΰ': 'υ',
+	'Ά': 'Α', 'Έ': 'Ε', 'Ή': 'Η', 'Ί': 'Ι', 'Ό': 'Ο', 'Ύ': 'Υ', 'Ώ': 'Ω',
+	'Ϊ': 'Ι', 'Ϋ': 'Υ',
+}
+
+// kana maps hiragana (and digraphs) to Latin, using Hepburn romanization.
+var kana = map[string]string{
+	"あ": "a", "い": "i", "う": "u", "え": "e", "お": "o",
+	"か": "ka", "き": "ki", "く": "ku", "け": "ke", "こ": "ko",
+	"さ": "sa", "し": "shi", "す": "su", "せ": "se", "そ": "so",
+	"た": "ta", "ち": "chi", "つ": "tsu", "て": "te", "と": "to",
+	"な": "na", "に": "ni", "ぬ": "nu", "ね": "ne", "の": "no",
+	"は": "ha", "ひ": "hi", "ふ": "fu", "へ": "he", "ほ": "ho",
+	"ま": "ma", "み": "mi", "む": "mu", "め": "me", "も": "mo",
+	"や": "ya", "ゆ": "yu", "よ": "yo",
+	"ら": "ra", "り": "ri", "る": "ru", "れ": "re", "ろ": "ro",
+	"わ": "wa", "ゐ": "i", "ゑ": "e", "を": "o", "ん": "n",
+	"が": "ga", "ぎ": "gi", "ぐ": "gu", "げ": "ge", "ご": "go",
+	"ざ": "za", "じ": "ji", "ず": "zu", "ぜ": "ze", "ぞ": "zo",
+	"だ": "da", "ぢ": "ji", "づ": "zu", "で": "de", "ど": "do",
+	"ば": "ba", "び": "bi", "ぶ": "bu", "べ": "be", "ぼ": "bo",
+	"ぱ": "pa", "ぴ": "pi", "ぷ": "pu", "ぺ": "pe", "ぽ": "po",
+	"ぁ": "a", "ぃ": "i", "ぅ": "u", "ぇ": "e", "ぉ": "o",
+	"ゃ": "ya", "ゅ": "yu", "ょ": "yo", "ゎ": "wa", "ゔ": "vu",
+	"ヷ": "va", "ヸ": "vi", "ヹ": "ve", "ヺ": "vo", "・": " ",
+	// Digraphs.
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho", "しぇ": "she",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho", "ちぇ": "che",
+	"にゃ": "nya", "にゅ": "nyu", "にょ": "nyo",
+	"ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo",
+	"みゃ": "mya", "みゅ": "myu", "みょ": "myo",
+	"りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+	"ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"じゃ": "ja", "じゅ": "ju", "じょ": "jo", "じぇ": "je",
+	"びゃ": "bya", "びゅ": "byu", "びょ": "byo",
+	"ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo",
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo",
+	"てぃ": "ti", "でぃ": "di", "うぃ": "wi", "うぇ": "we", "うぉ": "wo",
+	"ゔぁ": "va", "ゔぃ": "vi", "ゔぇ": "ve", "ゔぉ": "vo",
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		addr address.Address
+		want address.Address
+	}{
+		// Cyrillic, with the region matched after transliteration.
+		{
+			address.Address{
+				Line1:       "ул. Тверская, д. 7",
+				Locality:    "Москва",
+				Region:      "Московская область",
+				PostalCode:  "125009",
+				CountryCode: "RU",
+			},
+			address.Address{
+				Line1:       "ul. Tverskaya, d. 7",
+				Locality:    "Moskva",
+				Region:      "MOS",
+				PostalCode:  "125009",
+				CountryCode: "RU",
+			},
+		},
+		// Ukrainian, uppercase words.
+		{
+			address.Address{
+				Line1:       "вул. Хрещатик, 22",
+				Locality:    "КИЇВ",
+				CountryCode: "UA",
+			},
+			address.Address{
+				Line1:       "vul. Khreshchatyk, 22",
+				Locality:    "KYIV",
+				CountryCode: "UA",
+			},
+		},
+		// Greek.
+		{
+			address.Address{
+				Line1:       "Οδός Ευριπίδου 10",
+				Locality:    "Αθήνα",
+				PostalCode:  "105 59",
+				CountryCode: "GR",
+			},
+			address.Address{
+				Line1:       "Odos Evripidou 10",
+				Locality:    "Athina",
+				PostalCode:  "105 59",
+				CountryCode: "GR",
+			},
+		},
+		// Kana, with long vowels omitted, and fullwidth characters, with the region matched via local regions.
+		{
+			address.Address{
+				Line1:       "１－１ ギンザ",
+				Line2:       "シャトー・キッチョウ",
+				Locality:    "ちゅうおうく",
+				Region:      "東京都",
+				PostalCode:  "１００－０００１",
+				CountryCode: "JP",
+			},
+			address.Address{
+				Line1:       "1-1 Ginza",
+				Line2:       "Shato Kitcho",
+				Locality:    "Chuoku",
+				Region:      "13",
+				PostalCode:  "100-0001",
+				CountryCode: "JP",
+			},
+		},
+		// Latin addresses are left unchanged.
+		{
+			address.Address{
+				Line1:       "1098 Alta Ave",
+				Locality:    "Mountain View",
+				Region:      "CA",
+				PostalCode:  "94043",
+				CountryCode: "US",
+			},
+			address.Address{
+				Line1:       "1098 Alta Ave",
+				Locality:    "Mountain View",
+				Region:      "CA",
+				PostalCode:  "94043",
+				CountryCode: "US",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := address.Transliterate(tt.addr)
+			if got != tt.want {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatTransliterate(t *testing.T) {
+	locale := address.NewLocale("ja")
+	formatter := address.NewFormatter(locale)
+	formatter.Transliterate = true
+	addr := address.Address{
+		Line1:       "１－１ ギンザ",
+		Locality:    "ちゅうおうく",
+		Region:      "13",
+		PostalCode:  "100-0001",
+		CountryCode: "JP",
+	}
+	wantLines := []string{
+		"1-1 Ginza",
+		"Chuoku, Tokyo",
+		"100-0001",
+		"Japan",
+	}
+	got := formatter.FormatText(addr)
+	want := strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+const (
+	// UBLAggregateNamespace is the UBL namespace of aggregate components (cac).
+	UBLAggregateNamespace = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	// UBLBasicNamespace is the UBL namespace of basic components (cbc).
+	UBLBasicNamespace = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+)
+
+// UBLPostalAddress represents a UBL postal address (cac:PostalAddress).
+//
+// Elements are encoded in UBL schema order, within the UBL namespaces.
+type UBLPostalAddress struct {
+	XMLName              xml.Name         `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2 PostalAddress"`
+	Postbox              string           `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 Postbox,omitempty"`
+	StreetName           string           `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 StreetName,omitempty"`
+	AdditionalStreetName string           `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 AdditionalStreetName,omitempty"`
+	BuildingNumber       string           `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 BuildingNumber,omitempty"`
+	CitySubdivisionName  string           `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 CitySubdivisionName,omitempty"`
+	CityName             string           `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 CityName,omitempty"`
+	PostalZone           string           `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 PostalZone,omitempty"`
+	CountrySubentity     string           `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 CountrySubentity,omitempty"`
+	CountrySubentityCode string           `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 CountrySubentityCode,omitempty"`
+	AddressLines         []UBLAddressLine `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2 AddressLine,omitempty"`
+	Country              *UBLCountry      `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2 Country,omitempty"`
+}
+
+// UBLAddressLine represents a UBL address line (cac:AddressLine).
+type UBLAddressLine struct {
+	Line string `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 Line"`
+}
+
+// UBLCountry represents a UBL country (cac:Country).
+type UBLCountry struct {
+	IdentificationCode string `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 IdentificationCode,omitempty"`
+	Name               string `xml:"urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2 Name,omitempty"`
+}
+
+// NewUBLPostalAddress converts the given address to a UBL postal address.
+//
+// Address lines are mapped as defined by EN 16931 (e.g. Peppol BIS Billing):
+// the first line to StreetName, the second to AdditionalStreetName, and
+// the third to an AddressLine. For predefined regions, the region ID is used
+// as the CountrySubentityCode, and the region name as the CountrySubentity.
+func NewUBLPostalAddress(addr Address) UBLPostalAddress {
+	p := UBLPostalAddress{
+		StreetName:           addr.Line1,
+		AdditionalStreetName: addr.Line2,
+		CitySubdivisionName:  addr.Sublocality,
+		CityName:             addr.Locality,
+		PostalZone:           addr.PostalCode,
+		CountrySubentity:     addr.Region,
+	}
+	if addr.Line3 != "" {
+		p.AddressLines = []UBLAddressLine{{Line: addr.Line3}}
+	}
+	if addr.Region != "" {
+		if name, ok := GetFormat(addr.CountryCode).Regions.Get(addr.Region); ok {
+			p.CountrySubentity = name
+			p.CountrySubentityCode = addr.Region
+		}
+	}
+	if addr.CountryCode != "" {
+		p.Country = &UBLCountry{IdentificationCode: addr.CountryCode}
+	}
+	return p
+}
+
+// Address converts the postal address to an address.
+//
+// A separate BuildingNumber is joined with the StreetName, using the order
+// of the country. The Postbox and the address lines follow the street lines.
+// Lines that don't fit into the three address lines are joined into the third.
+// The region is resolved from the CountrySubentityCode if it matches a
+// predefined region, otherwise from the CountrySubentity.
+func (p UBLPostalAddress) Address() Address {
+	countryCode := ""
+	if p.Country != nil {
+		countryCode = strings.ToUpper(strings.TrimSpace(p.Country.IdentificationCode))
+		if countryCode == "" && p.Country.Name != "" {
+			countryCode, _ = lookupCountryCode(p.Country.Name)
+		}
+	}
+	format := GetFormat(countryCode)
+	var lines []string
+	for _, line := range []string{joinStreet(countryCode, p.StreetName, p.BuildingNumber), p.AdditionalStreetName, p.Postbox} {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	for _, addressLine := range p.AddressLines {
+		if line := strings.TrimSpace(addressLine.Line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	addr := Address{
+		Sublocality: strings.TrimSpace(p.CitySubdivisionName),
+		Locality:    strings.TrimSpace(p.CityName),
+		Region:      lookupRegion(format, strings.TrimSpace(p.CountrySubentity)),
+		PostalCode:  strings.TrimSpace(p.PostalZone),
+		CountryCode: countryCode,
+	}
+	if format.Regions.HasKey(p.CountrySubentityCode) {
+		addr.Region = p.CountrySubentityCode
+	}
+	if len(lines) > 0 {
+		addr.Line1 = lines[0]
+	}
+	if len(lines) > 1 {
+		addr.Line2 = lines[1]
+	}
+	if len(lines) > 2 {
+		addr.Line3 = strings.Join(lines[2:], ", ")
+	}
+	return addr
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestNewUBLPostalAddress(t *testing.T) {
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Line2:       "Suite 200",
+		Line3:       "Building B",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	got := address.NewUBLPostalAddress(addr)
+	want := address.UBLPostalAddress{
+		StreetName:           "1098 Alta Ave",
+		AdditionalStreetName: "Suite 200",
+		CityName:             "Mountain View",
+		PostalZone:           "94043",
+		CountrySubentity:     "California",
+		CountrySubentityCode: "CA",
+		AddressLines:         []address.UBLAddressLine{{Line: "Building B"}},
+		Country:              &address.UBLCountry{IdentificationCode: "US"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+	if back := got.Address(); back != addr {
+		t.Errorf("got %#v, want %#v", back, addr)
+	}
+
+	// Regions without predefined values are used as-is.
+	got = address.NewUBLPostalAddress(address.Address{Line1: "Hauptstraße 5", Region: "Berlin", CountryCode: "DE"})
+	if got.CountrySubentity != "Berlin" || got.CountrySubentityCode != "" {
+		t.Errorf("got %q, %q, want Berlin and an empty code", got.CountrySubentity, got.CountrySubentityCode)
+	}
+}
+
+func TestUBLPostalAddress_Address(t *testing.T) {
+	tests := []struct {
+		name string
+		p    address.UBLPostalAddress
+		want address.Address
+	}{
+		{
+			"building number",
+			address.UBLPostalAddress{StreetName: "Calle Numa", BuildingNumber: "55", CityName: "Dos Hermanas", PostalZone: "41089", CountrySubentity: "Sevilla", Country: &address.UBLCountry{IdentificationCode: "ES"}},
+			address.Address{Line1: "Calle Numa 55", Locality: "Dos Hermanas", Region: "SE", PostalCode: "41089", CountryCode: "ES"},
+		},
+		{
+			"country name",
+			address.UBLPostalAddress{StreetName: "Alta Ave", BuildingNumber: "1098", CityName: "Mountain View", CountrySubentity: "Calif.", CountrySubentityCode: "CA", Country: &address.UBLCountry{Name: "United States"}},
+			address.Address{Line1: "1098 Alta Ave", Locality: "Mountain View", Region: "CA", CountryCode: "US"},
+		},
+		{
+			"postbox and extra lines",
+			address.UBLPostalAddress{StreetName: "Main St 1", Postbox: "PO Box 12", AddressLines: []address.UBLAddressLine{{Line: "Attn: Billing"}, {Line: "Floor 2"}}, CityName: "Oslo", Country: &address.UBLCountry{IdentificationCode: "NO"}},
+			address.Address{Line1: "Main St 1", Line2: "PO Box 12", Line3: "Attn: Billing, Floor 2", Locality: "Oslo", CountryCode: "NO"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.p.Address()
+			if got != tt.want {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUBLPostalAddress_XML(t *testing.T) {
+	input := `<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+		xmlns:cac="urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+		xmlns:cbc="urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2">
+		<cac:AccountingSupplierParty><cac:Party>
+			<cac:PostalAddress>
+				<cbc:StreetName>Calle Numa 55</cbc:StreetName>
+				<cbc:AdditionalStreetName>Piso 3</cbc:AdditionalStreetName>
+				<cbc:CityName>Dos Hermanas</cbc:CityName>
+				<cbc:PostalZone>41089</cbc:PostalZone>
+				<cbc:CountrySubentity>Sevilla</cbc:CountrySubentity>
+				<cac:Country><cbc:IdentificationCode>ES</cbc:IdentificationCode></cac:Country>
+			</cac:PostalAddress>
+		</cac:Party></cac:AccountingSupplierParty>
+	</Invoice>`
+	var invoice struct {
+		Address address.UBLPostalAddress `xml:"AccountingSupplierParty>Party>PostalAddress"`
+	}
+	if err := xml.Unmarshal([]byte(input), &invoice); err != nil {
+		t.Fatal(err)
+	}
+	want := address.Address{Line1: "Calle Numa 55", Line2: "Piso 3", Locality: "Dos Hermanas", Region: "SE", PostalCode: "41089", CountryCode: "ES"}
+	if got := invoice.Address.Address(); got != want {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+
+	// Round trip.
+	data, err := xml.Marshal(address.NewUBLPostalAddress(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p address.UBLPostalAddress
+	if err := xml.Unmarshal(data, &p); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Address(); got != want {
+		t.Errorf("got %#v, want %#v\n%s", got, want, data)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import "fmt"
+
+// ValidationReason represents the reason why a field is invalid.
+type ValidationReason uint8
+
+const (
+	ValidationReasonRequired ValidationReason = iota
+	ValidationReasonTooLong
+	ValidationReasonInvalidScript
+	ValidationReasonInvalidRegion
+	ValidationReasonInvalidPostalCode
+)
+
+var validationReasonNames = [...]string{"required", "too_long", "invalid_script", "invalid_region", "invalid_postal_code"}
+
+// String returns the string representation of r.
+func (r ValidationReason) String() string {
+	if int(r) >= len(validationReasonNames) {
+		return ""
+	}
+	return validationReasonNames[r]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r ValidationReason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *ValidationReason) UnmarshalText(b []byte) error {
+	aux := string(b)
+	for i, name := range validationReasonNames {
+		if name == aux {
+			*r = ValidationReason(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid validation reason %q", aux)
+}
+
+// ValidationError represents an invalid address field.
+type ValidationError struct {
+	Field  Field            `json:"field"`
+	Reason ValidationReason `json:"reason"`
+	// MaxLength is the maximum length, set for ValidationReasonTooLong.
+	MaxLength int `json:"max_length,omitempty"`
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	switch e.Reason {
+	case ValidationReasonRequired:
+		return fmt.Sprintf("field %q is required", fieldName(e.Field))
+	case ValidationReasonTooLong:
+		return fmt.Sprintf("field %q is longer than %d characters", fieldName(e.Field), e.MaxLength)
+	case ValidationReasonInvalidScript:
+		return fmt.Sprintf("field %q contains characters from a disallowed script", fieldName(e.Field))
+	}
+	return fmt.Sprintf("field %q is invalid", fieldName(e.Field))
+}
+
+// Validate validates the given address against the address format.
+//
+// Fields are checked in a fixed order (line1, line2, line3, sublocality,
+// locality, region, postal code), returning at most one error per field.
+// Fields that are not used by the format's layout are not checked.
+// The country code is not checked, see CheckCountryCode().
+func (f Format) Validate(addr Address) []ValidationError {
+	layout := ParseLayout(f.Layout)
+	var errs []ValidationError
+	for _, field := range []Field{FieldLine1, FieldLine2, FieldLine3, FieldSublocality, FieldLocality, FieldRegion, FieldPostalCode} {
+		if !layout.HasField(field) {
+			continue
+		}
+		value := addr.getField(field)
+		switch {
+		case !f.CheckRequired(field, value):
+			errs = append(errs, ValidationError{Field: field, Reason: ValidationReasonRequired})
+		case !f.CheckLength(field, value):
+			errs = append(errs, ValidationError{Field: field, Reason: ValidationReasonTooLong, MaxLength: f.MaxLength(field)})
+		case field == FieldRegion && !f.CheckRegion(value):
+			errs = append(errs, ValidationError{Field: field, Reason: ValidationReasonInvalidRegion})
+		case field == FieldPostalCode && !f.CheckPostalCode(value):
+			errs = append(errs, ValidationError{Field: field, Reason: ValidationReasonInvalidPostalCode})
+		case field != FieldRegion && field != FieldPostalCode && !f.CheckScript(value):
+			errs = append(errs, ValidationError{Field: field, Reason: ValidationReasonInvalidScript})
+		}
+	}
+
+	return errs
+}
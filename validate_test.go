@@ -0,0 +1,167 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestFormat_Validate(t *testing.T) {
+	format := address.GetFormat("US")
+	format.MaxLengths = map[address.Field]int{
+		address.FieldLine1:    35,
+		address.FieldLocality: 30,
+	}
+	format.Scripts = []string{"Latn"}
+
+	// Valid address.
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	if errs := format.Validate(addr); len(errs) != 0 {
+		t.Errorf("got %v, want no errors", errs)
+	}
+
+	// Invalid address.
+	addr = address.Address{
+		Line1:       "1098 Alta Avenue, Building 5, Suite 100",
+		Line2:       "Корпус 5",
+		Region:      "XX",
+		PostalCode:  "9404",
+		CountryCode: "US",
+	}
+	want := []address.ValidationError{
+		{Field: address.FieldLine1, Reason: address.ValidationReasonTooLong, MaxLength: 35},
+		{Field: address.FieldLine2, Reason: address.ValidationReasonInvalidScript},
+		{Field: address.FieldLocality, Reason: address.ValidationReasonRequired},
+		{Field: address.FieldRegion, Reason: address.ValidationReasonInvalidRegion},
+		{Field: address.FieldPostalCode, Reason: address.ValidationReasonInvalidPostalCode},
+	}
+	got := format.Validate(addr)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFormat_Validate_CountryConstraints(t *testing.T) {
+	tests := []struct {
+		addr address.Address
+		want []address.ValidationError
+	}{
+		{
+			address.Address{
+				Line1:       "1098 Alta Avenue, Building 5, Suite 100, Attention: Receiving Dept",
+				Locality:    "Mountain View",
+				Region:      "CA",
+				PostalCode:  "94043",
+				CountryCode: "US",
+			},
+			[]address.ValidationError{
+				{Field: address.FieldLine1, Reason: address.ValidationReasonTooLong, MaxLength: 64},
+			},
+		},
+		{
+			address.Address{
+				Line1:       "Church Lane",
+				Locality:    "Llanfairpwllgwyngyllgogerychwyrndrobwllllantysiliogogogoch",
+				PostalCode:  "LL61 5UJ",
+				CountryCode: "GB",
+			},
+			[]address.ValidationError{
+				{Field: address.FieldLocality, Reason: address.ValidationReasonTooLong, MaxLength: 30},
+			},
+		},
+		{
+			address.Address{
+				Line1:       "ул. Тверская, 7",
+				Locality:    "Toronto",
+				Region:      "ON",
+				PostalCode:  "M5V 2T6",
+				CountryCode: "CA",
+			},
+			[]address.ValidationError{
+				{Field: address.FieldLine1, Reason: address.ValidationReasonInvalidScript},
+			},
+		},
+		{
+			address.Address{
+				Line1:       "ул. Тверская, 7",
+				Locality:    "Москва",
+				Region:      "MOW",
+				PostalCode:  "125009",
+				CountryCode: "RU",
+			},
+			nil,
+		},
+		{
+			address.Address{
+				Line1:       "丸の内センタービル1-1",
+				Locality:    "千代田区",
+				Region:      "13",
+				PostalCode:  "100-0005",
+				CountryCode: "JP",
+			},
+			nil,
+		},
+		{
+			address.Address{
+				Line1:       "Οδός Ερμού 10",
+				Locality:    "Москва",
+				PostalCode:  "105 63",
+				CountryCode: "GR",
+			},
+			[]address.ValidationError{
+				{Field: address.FieldLocality, Reason: address.ValidationReasonInvalidScript},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr.CountryCode, func(t *testing.T) {
+			got := address.GetFormat(tt.addr.CountryCode).Validate(tt.addr)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	tests := []struct {
+		err  address.ValidationError
+		want string
+	}{
+		{address.ValidationError{Field: address.FieldLocality, Reason: address.ValidationReasonRequired}, `field "locality" is required`},
+		{address.ValidationError{Field: address.FieldLine1, Reason: address.ValidationReasonTooLong, MaxLength: 35}, `field "line1" is longer than 35 characters`},
+		{address.ValidationError{Field: address.FieldLine2, Reason: address.ValidationReasonInvalidScript}, `field "line2" contains characters from a disallowed script`},
+		{address.ValidationError{Field: address.FieldPostalCode, Reason: address.ValidationReasonInvalidPostalCode}, `field "postal_code" is invalid`},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationReason_MarshalText(t *testing.T) {
+	b, _ := address.ValidationReasonTooLong.MarshalText()
+	if string(b) != "too_long" {
+		t.Errorf("got %s, want too_long", b)
+	}
+	var r address.ValidationReason
+	if err := r.UnmarshalText([]byte("invalid_region")); err != nil || r != address.ValidationReasonInvalidRegion {
+		t.Errorf("got %v, %v, want %v", r, err, address.ValidationReasonInvalidRegion)
+	}
+	if err := r.UnmarshalText([]byte("galaxy")); err == nil {
+		t.Error("expected an error for an invalid validation reason.")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// FormatVCard formats the given address as a vCard 4.0 ADR property.
+//
+// The LABEL parameter contains the formatted address, while the CC parameter
+// contains the country code (RFC 8605). The sublocality is appended to the
+// street address, since vCard has no equivalent component.
+//
+// Lines longer than 75 octets are folded, as required by RFC 6350.
+func (f *Formatter) FormatVCard(addr Address) string {
+	if addr.IsEmpty() {
+		return ""
+	}
+	format := f.getFormat(addr.CountryCode)
+	values := f.getValues(addr, format, f.selectLocale(addr, format))
+	var street []string
+	for _, field := range []Field{FieldLine1, FieldLine2, FieldLine3, FieldSublocality} {
+		if values.get(field) != "" {
+			street = append(street, escapeVCardValue(values.get(field)))
+		}
+	}
+	components := []string{
+		"", // Post office box.
+		"", // Extended address.
+		strings.Join(street, ","),
+		escapeVCardValue(values.get(FieldLocality)),
+		escapeVCardValue(values.get(FieldRegion)),
+		escapeVCardValue(values.get(FieldPostalCode)),
+		escapeVCardValue(f.countryName(addr.CountryCode)),
+	}
+	label := f.FormatText(addr)
+
+	sb := strings.Builder{}
+	sb.Grow(200)
+	sb.WriteString("ADR;CC=")
+	sb.WriteString(addr.CountryCode)
+	sb.WriteString(`;LABEL="`)
+	sb.WriteString(escapeVCardParam(label))
+	sb.WriteString(`":`)
+	sb.WriteString(strings.Join(components, ";"))
+
+	return foldVCardLine(sb.String())
+}
+
+// ParseVCardADR parses the given vCard ADR property into an address.
+//
+// Both vCard 3.0 and 4.0 properties are supported, including folded lines.
+// The country is resolved from the CC parameter if present, otherwise
+// from the country component, which can be either a country code or an
+// English country name. Region names are converted to region IDs when
+// the address format has predefined regions.
+func ParseVCardADR(property string) (Address, error) {
+	property = unfoldVCardLine(property)
+	n := indexUnquoted(property, ':')
+	if n == -1 {
+		return Address{}, fmt.Errorf("invalid ADR property %q: missing value", property)
+	}
+	params := splitUnquoted(property[:n], ';')
+	name := params[0]
+	// Remove the group prefix, if any (e.g. "item1.ADR").
+	if dot := strings.LastIndexByte(name, '.'); dot != -1 {
+		name = name[dot+1:]
+	}
+	if !strings.EqualFold(name, "ADR") {
+		return Address{}, fmt.Errorf("invalid ADR property %q: unexpected name %q", property, name)
+	}
+	countryCode := ""
+	for _, param := range params[1:] {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) == 2 && strings.EqualFold(kv[0], "CC") {
+			countryCode = strings.ToUpper(strings.Trim(kv[1], `"`))
+		}
+	}
+
+	components := splitVCardValue(property[n+1:], ';')
+	for len(components) < 7 {
+		components = append(components, "")
+	}
+	var lines []string
+	if poBox := unescapeVCardValue(components[0]); poBox != "" {
+		lines = append(lines, poBox)
+	}
+	for _, component := range splitVCardValue(components[2], ',') {
+		if line := unescapeVCardValue(component); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if extended := unescapeVCardValue(components[1]); extended != "" {
+		lines = append(lines, extended)
+	}
+	if countryCode == "" || !CheckCountryCode(countryCode) {
+		country := unescapeVCardValue(components[6])
+		var ok bool
+		countryCode, ok = lookupCountryCode(country)
+		if !ok {
+			return Address{}, fmt.Errorf("invalid ADR property %q: unknown country %q", property, country)
+		}
+	}
+
+	addr := Address{
+		Locality:    unescapeVCardValue(components[3]),
+		PostalCode:  unescapeVCardValue(components[5]),
+		CountryCode: countryCode,
+	}
+	addr.Region = lookupRegion(GetFormat(countryCode), unescapeVCardValue(components[4]))
+	if len(lines) > 0 {
+		addr.Line1 = lines[0]
+	}
+	if len(lines) > 1 {
+		addr.Line2 = lines[1]
+	}
+	if len(lines) > 2 {
+		addr.Line3 = strings.Join(lines[2:], ", ")
+	}
+
+	return addr, nil
+}
+
+// escapeVCardValue escapes the given vCard property value.
+func escapeVCardValue(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// unescapeVCardValue unescapes the given vCard property value.
+func unescapeVCardValue(s string) string {
+	r := strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, "\n", `\N`, "\n")
+	return strings.TrimSpace(r.Replace(s))
+}
+
+// escapeVCardParam escapes the given vCard parameter value (RFC 6868).
+func escapeVCardParam(s string) string {
+	r := strings.NewReplacer("^", "^^", "\n", "^n", `"`, "^'")
+	return r.Replace(s)
+}
+
+// splitVCardValue splits the given vCard property value on unescaped separators.
+func splitVCardValue(s string, sep byte) []string {
+	var parts []string
+	prev := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == sep {
+			parts = append(parts, s[prev:i])
+			prev = i + 1
+		}
+	}
+	return append(parts, s[prev:])
+}
+
+// splitUnquoted splits the given string on separators outside of quotes.
+func splitUnquoted(s string, sep byte) []string {
+	var parts []string
+	for {
+		n := indexUnquoted(s, sep)
+		if n == -1 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:n])
+		s = s[n+1:]
+	}
+}
+
+// indexUnquoted returns the index of the first c outside of quotes, or -1.
+func indexUnquoted(s string, c byte) int {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case c:
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// foldVCardLine folds the given content line into lines of at most 75 octets.
+func foldVCardLine(s string) string {
+	if len(s) <= 75 {
+		return s
+	}
+	sb := strings.Builder{}
+	sb.Grow(len(s) + len(s)/74*3)
+	limit := 75
+	for len(s) > limit {
+		// Avoid splitting multi-byte characters.
+		n := limit
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		sb.WriteString(s[:n])
+		sb.WriteString("\r\n ")
+		s = s[n:]
+		// Continuation lines start with a space.
+		limit = 74
+	}
+	sb.WriteString(s)
+
+	return sb.String()
+}
+
+// unfoldVCardLine unfolds the given content line.
+func unfoldVCardLine(s string) string {
+	r := strings.NewReplacer("\r\n ", "", "\r\n\t", "", "\n ", "", "\n\t", "")
+	return strings.TrimRight(r.Replace(s), "\r\n")
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestFormatter_FormatVCard(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+
+	// Empty address.
+	if got := formatter.FormatVCard(address.Address{}); got != "" {
+		t.Errorf("got: %v, want an empty string", got)
+	}
+
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Line2:       "Suite 5; Floor 2",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	got := formatter.FormatVCard(addr)
+	want := "ADR;CC=US;LABEL=\"1098 Alta Ave^nSuite 5; Floor 2^nMountain View, CA 94043^n\r\n" +
+		" United States\":;;1098 Alta Ave,Suite 5\\; Floor 2;Mountain View;CA;94043;Un\r\n" +
+		" ited States"
+	if got != want {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+	for _, line := range strings.Split(got, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line %q is longer than 75 octets", line)
+		}
+	}
+
+	// Round-trip.
+	parsed, err := address.ParseVCardADR(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != addr {
+		t.Errorf("got %v, want %v", parsed, addr)
+	}
+}
+
+func TestParseVCardADR(t *testing.T) {
+	tests := []struct {
+		property string
+		want     address.Address
+		wantErr  bool
+	}{
+		// Missing value.
+		{"ADR;TYPE=home", address.Address{}, true},
+		// Wrong property.
+		{"TEL:+1-555-555-5555", address.Address{}, true},
+		// Unknown country.
+		{"ADR:;;1 Main St;Springfield;;;Atlantis", address.Address{}, true},
+		// vCard 3.0, country name and region name.
+		{"ADR;TYPE=WORK:;;Calle Numa 55;Dos Hermanas;Sevilla;41089;Spain\r\n", address.Address{
+			Line1:       "Calle Numa 55",
+			Locality:    "Dos Hermanas",
+			Region:      "SE",
+			PostalCode:  "41089",
+			CountryCode: "ES",
+		}, false},
+		// Grouped property, quoted label with a colon, post office box and extended address.
+		{`item1.adr;label="PO Box 12: Main":PO Box 12;Apt 3;1 Main St;Belgrade;;11000;serbia`, address.Address{
+			Line1:       "PO Box 12",
+			Line2:       "1 Main St",
+			Line3:       "Apt 3",
+			Locality:    "Belgrade",
+			PostalCode:  "11000",
+			CountryCode: "RS",
+		}, false},
+		// Country name with "and", country code as the country component.
+		{"ADR:;;Ferhadija 1;Sarajevo;;71000;Bosnia and Herzegovina", address.Address{
+			Line1:       "Ferhadija 1",
+			Locality:    "Sarajevo",
+			PostalCode:  "71000",
+			CountryCode: "BA",
+		}, false},
+		{"ADR:;;Ferhadija 1;Sarajevo;;71000;ba", address.Address{
+			Line1:       "Ferhadija 1",
+			Locality:    "Sarajevo",
+			PostalCode:  "71000",
+			CountryCode: "BA",
+		}, false},
+		// CC parameter takes precedence, local region names are recognized.
+		{"ADR;CC=jp:;;1-1 Marunouchi;千代田区;東京都;100-0001;Japon", address.Address{
+			Line1:       "1-1 Marunouchi",
+			Locality:    "千代田区",
+			Region:      "13",
+			PostalCode:  "100-0001",
+			CountryCode: "JP",
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got, err := address.ParseVCardADR(tt.property)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatMicroformats(t *testing.T) {
+	locale := address.NewLocale("en")
+	formatter := address.NewFormatter(locale)
+	formatter.StructuredData = address.StructuredDataMicroformats
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Line2:       "Suite 5",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	wantLines := []string{
+		`<p class="address h-adr" translate="no">`,
+		`<span class="line1 p-street-address">1098 Alta Ave</span><br>`,
+		`<span class="line2 p-extended-address">Suite 5</span><br>`,
+		`<span class="locality p-locality">Mountain View</span>, <span class="region p-region">CA</span> <span class="postal-code p-postal-code">94043</span><br>`,
+		`<span class="country p-country-name" data-value="US">United States</span>`,
+		`</p>`,
+	}
+	got := formatter.Format(addr)
+	want := strings.Join(wantLines, "\n")
+	if got != want {
+		t.Errorf("got:\n%v\nwant:\n%v", got, want)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// XMLNames holds the XML element names of an address.
+//
+// An empty field name skips the field.
+type XMLNames struct {
+	// Address is the name of the address element.
+	// Defaults to an empty string, in which case the name is
+	// determined by encoding/xml (e.g. from the struct field tag).
+	Address     string
+	Line1       string
+	Line2       string
+	Line3       string
+	Sublocality string
+	Locality    string
+	Region      string
+	PostalCode  string
+	CountryCode string
+}
+
+// DefaultXMLNames are the XML element names used by XMLAddress when no
+// names are set.
+//
+// They match the JSON representation of Address.
+var DefaultXMLNames = XMLNames{
+	Line1:       "line1",
+	Line2:       "line2",
+	Line3:       "line3",
+	Sublocality: "sublocality",
+	Locality:    "locality",
+	Region:      "region",
+	PostalCode:  "postal_code",
+	CountryCode: "country",
+}
+
+// XMLAddress is an address with custom XML element names.
+//
+// For example, element names used by an internal API:
+//
+//	names := address.XMLNames{
+//		Address:     "ShipTo",
+//		Line1:       "Street1",
+//		Line2:       "Street2",
+//		Locality:    "City",
+//		Region:      "State",
+//		PostalCode:  "Zip",
+//		CountryCode: "Country",
+//	}
+//	data, err := xml.Marshal(address.XMLAddress{Address: addr, Names: names})
+//
+// Names are local names only, namespaced formats such as UBL are
+// supported by UBLPostalAddress instead.
+//
+// Empty fields are omitted when marshalling. When unmarshalling, Names
+// must be set before decoding, and unknown elements are ignored.
+//
+// Address itself keeps the default encoding/xml representation, which
+// uses the Go field names (e.g. <Line1>).
+type XMLAddress struct {
+	Address
+	// Names are the XML element names.
+	// Defaults to DefaultXMLNames.
+	Names XMLNames
+}
+
+// MarshalXML implements the xml.Marshaler interface.
+func (x XMLAddress) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLAddress(e, start, x.Address, x.names())
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (x *XMLAddress) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXMLAddress(d, &x.Address, x.names())
+}
+
+// names returns the element names, falling back to DefaultXMLNames.
+func (x XMLAddress) names() XMLNames {
+	if x.Names == (XMLNames{}) {
+		return DefaultXMLNames
+	}
+	return x.Names
+}
+
+// xmlField holds the element name and a pointer to the value of an address field.
+type xmlField struct {
+	name  string
+	value *string
+}
+
+// xmlFields returns the address fields, in encoding order.
+func xmlFields(a *Address, names XMLNames) [8]xmlField {
+	return [8]xmlField{
+		{names.Line1, &a.Line1},
+		{names.Line2, &a.Line2},
+		{names.Line3, &a.Line3},
+		{names.Sublocality, &a.Sublocality},
+		{names.Locality, &a.Locality},
+		{names.Region, &a.Region},
+		{names.PostalCode, &a.PostalCode},
+		{names.CountryCode, &a.CountryCode},
+	}
+}
+
+// marshalXMLAddress encodes the given address using the given element names.
+func marshalXMLAddress(e *xml.Encoder, start xml.StartElement, a Address, names XMLNames) error {
+	if names.Address != "" {
+		start.Name = xml.Name{Local: names.Address}
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, field := range xmlFields(&a, names) {
+		if field.name == "" || *field.value == "" {
+			continue
+		}
+		if err := e.EncodeElement(*field.value, xml.StartElement{Name: xml.Name{Local: field.name}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// unmarshalXMLAddress decodes an address using the given element names.
+func unmarshalXMLAddress(d *xml.Decoder, a *Address, names XMLNames) error {
+	fields := xmlFields(a, names)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value *string
+			for _, field := range fields {
+				if field.name != "" && field.name == t.Name.Local {
+					value = field.value
+					break
+				}
+			}
+			if value == nil {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			var s string
+			if err := d.DecodeElement(&s, &t); err != nil {
+				return err
+			}
+			*value = strings.TrimSpace(s)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestAddress_XML(t *testing.T) {
+	// Address keeps the default encoding/xml representation.
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	data, err := xml.Marshal(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<Address><Line1>1098 Alta Ave</Line1><Line2></Line2><Line3></Line3><Sublocality></Sublocality><Locality>Mountain View</Locality><Region>CA</Region><PostalCode>94043</PostalCode><CountryCode>US</CountryCode></Address>"
+	if string(data) != want {
+		t.Errorf("got %v, want %v", string(data), want)
+	}
+	var got address.Address
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != addr {
+		t.Errorf("got %#v, want %#v", got, addr)
+	}
+}
+
+func TestXMLAddress_DefaultNames(t *testing.T) {
+	addr := address.Address{
+		Line1:       "1098 Alta Ave",
+		Locality:    "Mountain View",
+		Region:      "CA",
+		PostalCode:  "94043",
+		CountryCode: "US",
+	}
+	data, err := xml.Marshal(address.XMLAddress{Address: addr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<XMLAddress><line1>1098 Alta Ave</line1><locality>Mountain View</locality><region>CA</region><postal_code>94043</postal_code><country>US</country></XMLAddress>"
+	if string(data) != want {
+		t.Errorf("got %v, want %v", string(data), want)
+	}
+	var got address.XMLAddress
+	if err := xml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Address != addr {
+		t.Errorf("got %#v, want %#v", got.Address, addr)
+	}
+
+	// Nested, with escaping.
+	type order struct {
+		XMLName  xml.Name           `xml:"order"`
+		ID       string             `xml:"id"`
+		Shipping address.XMLAddress `xml:"shipping"`
+	}
+	o := order{ID: "1", Shipping: address.XMLAddress{Address: address.Address{Line1: "Smith & Sons", CountryCode: "GB"}}}
+	data, err = xml.Marshal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "<order><id>1</id><shipping><line1>Smith &amp; Sons</line1><country>GB</country></shipping></order>"
+	if string(data) != want {
+		t.Errorf("got %v, want %v", string(data), want)
+	}
+	var gotOrder order
+	if err := xml.Unmarshal(data, &gotOrder); err != nil {
+		t.Fatal(err)
+	}
+	if gotOrder.Shipping.Address != o.Shipping.Address || gotOrder.ID != "1" {
+		t.Errorf("got %#v, want %#v", gotOrder, o)
+	}
+}
+
+func TestXMLAddress(t *testing.T) {
+	names := address.XMLNames{
+		Address:     "PostalAddress",
+		Line1:       "StreetName",
+		Line2:       "AdditionalStreetName",
+		Locality:    "CityName",
+		Region:      "CountrySubentity",
+		PostalCode:  "PostalZone",
+		CountryCode: "Country",
+	}
+	addr := address.Address{
+		Line1:       "Calle Numa 55",
+		Line3:       "Skipped",
+		Locality:    "Dos Hermanas",
+		Region:      "SE",
+		PostalCode:  "41089",
+		CountryCode: "ES",
+	}
+	data, err := xml.Marshal(address.XMLAddress{Address: addr, Names: names})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<PostalAddress><StreetName>Calle Numa 55</StreetName><CityName>Dos Hermanas</CityName><CountrySubentity>SE</CountrySubentity><PostalZone>41089</PostalZone><Country>ES</Country></PostalAddress>"
+	if string(data) != want {
+		t.Errorf("got %v, want %v", string(data), want)
+	}
+
+	input := `<PostalAddress>
+		<StreetName> Calle Numa 55 </StreetName>
+		<BuildingNumber>55</BuildingNumber>
+		<CityName>Dos Hermanas</CityName>
+		<AddressLine><Line>Ignored</Line></AddressLine>
+		<CountrySubentity>SE</CountrySubentity>
+		<PostalZone>41089</PostalZone>
+		<Country>ES</Country>
+	</PostalAddress>`
+	got := address.XMLAddress{Names: names}
+	if err := xml.Unmarshal([]byte(input), &got); err != nil {
+		t.Fatal(err)
+	}
+	addr.Line3 = ""
+	if got.Address != addr {
+		t.Errorf("got %#v, want %#v", got.Address, addr)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Zone represents a customs or tax zone.
+type Zone uint8
+
+const (
+	// ZoneEUVAT is the EU VAT area.
+	ZoneEUVAT Zone = iota
+	// ZoneEUCustoms is the EU customs union.
+	ZoneEUCustoms
+	// ZoneUKVAT is the UK VAT area, including the Isle of Man.
+	ZoneUKVAT
+	// ZoneNorthernIreland is Northern Ireland, which follows EU rules for goods.
+	ZoneNorthernIreland
+)
+
+var zoneNames = [...]string{"eu_vat", "eu_customs", "uk_vat", "northern_ireland"}
+
+// String returns the string representation of z.
+func (z Zone) String() string {
+	if int(z) >= len(zoneNames) {
+		return ""
+	}
+	return zoneNames[z]
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (z Zone) MarshalText() ([]byte, error) {
+	return []byte(z.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (z *Zone) UnmarshalText(b []byte) error {
+	aux := string(b)
+	for i, name := range zoneNames {
+		if name == aux {
+			*z = Zone(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid zone %q", aux)
+}
+
+// InZone returns whether the address is inside the given zone.
+//
+// Zones are made of countries and areas of countries, minus any excluded
+// areas. Areas (e.g. Northern Ireland, the Canary Islands, Åland,
+// Mount Athos, Büsingen) are detected by their own country code if they
+// have one, or by the address region or postal code.
+//
+// Region names are resolved using the built-in formats, since zones
+// are defined in terms of the built-in region IDs. Registry overrides
+// are not taken into account.
+func (a Address) InZone(zone Zone) bool {
+	data, ok := zones[zone]
+	if !ok {
+		return false
+	}
+	inZone := contains(data.countryCodes, a.CountryCode)
+	for _, area := range data.areas {
+		if area.matches(a) {
+			inZone = true
+			break
+		}
+	}
+	if !inZone {
+		return false
+	}
+	for _, excluded := range data.excluded {
+		if excluded.matches(a) {
+			return false
+		}
+	}
+	return true
+}
+
+// Zones returns all zones that the address is inside of.
+func (a Address) Zones() []Zone {
+	var zs []Zone
+	for i := range zoneNames {
+		if a.InZone(Zone(i)) {
+			zs = append(zs, Zone(i))
+		}
+	}
+	return zs
+}
+
+// zoneData holds the countries, areas and excluded areas of a zone.
+type zoneData struct {
+	countryCodes []string
+	areas        []zoneArea
+	excluded     []zoneArea
+}
+
+// zoneArea represents a part of a country, identified by regions or postal codes.
+type zoneArea struct {
+	countryCode    string
+	regions        []string
+	postalPrefixes []string
+}
+
+// matches returns whether the given address is inside the area.
+func (z zoneArea) matches(a Address) bool {
+	if a.CountryCode != z.countryCode {
+		return false
+	}
+	if len(z.regions) > 0 && a.Region != "" {
+		region := lookupRegion(GetFormat(a.CountryCode), a.Region)
+		if contains(z.regions, region) {
+			return true
+		}
+	}
+	postalCode := strings.ToUpper(strings.ReplaceAll(a.PostalCode, " ", ""))
+	if postalCode == "" {
+		return false
+	}
+	for _, prefix := range z.postalPrefixes {
+		if strings.HasPrefix(postalCode, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains returns whether the given slice contains the given value.
+func contains(a []string, x string) bool {
+	for _, v := range a {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
+
+// euCountryCodes are the EU member states.
+var euCountryCodes = []string{
+	"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "ES", "FI", "FR", "GR", "HR", "HU",
+	"IE", "IT", "LT", "LU", "LV", "MT", "NL", "PL", "PT", "RO", "SE", "SI", "SK",
+}
+
+// zones are the zone definitions.
+var zones = map[Zone]zoneData{
+	ZoneEUVAT: {
+		// Monaco is part of the French VAT area.
+		countryCodes: append([]string{"MC"}, euCountryCodes...),
+		excluded: []zoneArea{
+			// Heligoland, Büsingen am Hochrhein.
+			{countryCode: "DE", postalPrefixes: []string{"27498", "78266"}},
+			// Canary Islands, Ceuta, Melilla.
+			{countryCode: "ES", regions: []string{"GC", "TF", "CE", "ML"}, postalPrefixes: []string{"35", "38", "51", "52"}},
+			// Åland.
+			{countryCode: "FI", postalPrefixes: []string{"22"}},
+			// Overseas departments and collectivities.
+			{countryCode: "FR", postalPrefixes: []string{"97", "98"}},
+			// Mount Athos.
+			{countryCode: "GR", postalPrefixes: []string{"63086", "63087"}},
+			// Livigno, Campione d'Italia.
+			{countryCode: "IT", postalPrefixes: []string{"23041", "22061"}},
+		},
+	},
+	ZoneEUCustoms: {
+		// Monaco and San Marino are in a customs union with the EU.
+		// Overseas departments, the Canary Islands and Åland have their own
+		// country codes, but are part of the EU customs union.
+		countryCodes: append([]string{"MC", "SM", "AX", "GF", "GP", "IC", "MF", "MQ", "RE", "YT"}, euCountryCodes...),
+		excluded: []zoneArea{
+			// Heligoland, Büsingen am Hochrhein.
+			{countryCode: "DE", postalPrefixes: []string{"27498", "78266"}},
+			// Ceuta, Melilla.
+			{countryCode: "ES", regions: []string{"CE", "ML"}, postalPrefixes: []string{"51", "52"}},
+			// Overseas collectivities (Saint Pierre and Miquelon, Saint Barthélemy,
+			// Wallis and Futuna, French Polynesia, New Caledonia).
+			// Saint Martin (97150) shares the 971 prefix with Guadeloupe and is
+			// an outermost region, so it stays in the customs union.
+			{countryCode: "FR", postalPrefixes: []string{"975", "97133", "98"}},
+			// Livigno.
+			{countryCode: "IT", postalPrefixes: []string{"23041"}},
+		},
+	},
+	ZoneUKVAT: {
+		countryCodes: []string{"GB", "IM"},
+	},
+	ZoneNorthernIreland: {
+		areas: []zoneArea{
+			{countryCode: "GB", postalPrefixes: []string{"BT"}},
+		},
+	},
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package address_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bojanz/address"
+)
+
+func TestAddress_InZone(t *testing.T) {
+	tests := []struct {
+		name     string
+		addr     address.Address
+		vat      bool
+		customs  bool
+		ukVAT    bool
+		northern bool
+	}{
+		{"Germany", address.Address{PostalCode: "10117", CountryCode: "DE"}, true, true, false, false},
+		{"Büsingen", address.Address{PostalCode: "78266", CountryCode: "DE"}, false, false, false, false},
+		{"Heligoland", address.Address{PostalCode: "27498", CountryCode: "DE"}, false, false, false, false},
+		{"Madrid", address.Address{Region: "M", PostalCode: "28001", CountryCode: "ES"}, true, true, false, false},
+		{"Canary Islands by region", address.Address{Region: "TF", CountryCode: "ES"}, false, true, false, false},
+		{"Canary Islands by region name", address.Address{Region: "Las Palmas", CountryCode: "ES"}, false, true, false, false},
+		{"Canary Islands by postal code", address.Address{PostalCode: "35001", CountryCode: "ES"}, false, true, false, false},
+		{"Canary Islands by country code", address.Address{CountryCode: "IC"}, false, true, false, false},
+		{"Melilla", address.Address{Region: "ML", PostalCode: "52001", CountryCode: "ES"}, false, false, false, false},
+		{"Åland by postal code", address.Address{PostalCode: "22100", CountryCode: "FI"}, false, true, false, false},
+		{"Åland by country code", address.Address{PostalCode: "22100", CountryCode: "AX"}, false, true, false, false},
+		{"Mount Athos", address.Address{PostalCode: "630 86", CountryCode: "GR"}, false, true, false, false},
+		{"Réunion", address.Address{PostalCode: "97400", CountryCode: "FR"}, false, true, false, false},
+		{"Saint Barthélemy", address.Address{PostalCode: "97133", CountryCode: "FR"}, false, false, false, false},
+		{"Saint Martin", address.Address{PostalCode: "97150", CountryCode: "FR"}, false, true, false, false},
+		{"Saint Pierre and Miquelon", address.Address{PostalCode: "97500", CountryCode: "FR"}, false, false, false, false},
+		{"New Caledonia", address.Address{PostalCode: "98800", CountryCode: "FR"}, false, false, false, false},
+		{"Monaco", address.Address{PostalCode: "98000", CountryCode: "MC"}, true, true, false, false},
+		{"San Marino", address.Address{PostalCode: "47890", CountryCode: "SM"}, false, true, false, false},
+		{"London", address.Address{PostalCode: "SW1A 1AA", CountryCode: "GB"}, false, false, true, false},
+		{"Belfast", address.Address{PostalCode: "BT1 5GS", CountryCode: "GB"}, false, false, true, true},
+		{"Isle of Man", address.Address{PostalCode: "IM1 1AA", CountryCode: "IM"}, false, false, true, false},
+		{"Switzerland", address.Address{PostalCode: "8001", CountryCode: "CH"}, false, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.addr.InZone(address.ZoneEUVAT); got != tt.vat {
+				t.Errorf("EU VAT: got %v, want %v", got, tt.vat)
+			}
+			if got := tt.addr.InZone(address.ZoneEUCustoms); got != tt.customs {
+				t.Errorf("EU customs: got %v, want %v", got, tt.customs)
+			}
+			if got := tt.addr.InZone(address.ZoneUKVAT); got != tt.ukVAT {
+				t.Errorf("UK VAT: got %v, want %v", got, tt.ukVAT)
+			}
+			if got := tt.addr.InZone(address.ZoneNorthernIreland); got != tt.northern {
+				t.Errorf("Northern Ireland: got %v, want %v", got, tt.northern)
+			}
+		})
+	}
+}
+
+func TestAddress_Zones(t *testing.T) {
+	addr := address.Address{PostalCode: "BT1 5GS", CountryCode: "GB"}
+	want := []address.Zone{address.ZoneUKVAT, address.ZoneNorthernIreland}
+	if got := addr.Zones(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	addr = address.Address{PostalCode: "8001", CountryCode: "CH"}
+	if got := addr.Zones(); len(got) != 0 {
+		t.Errorf("got %v, want no zones", got)
+	}
+}
+
+func TestZone_MarshalText(t *testing.T) {
+	b, _ := address.ZoneEUCustoms.MarshalText()
+	if string(b) != "eu_customs" {
+		t.Errorf("got %s, want eu_customs", b)
+	}
+	var zone address.Zone
+	if err := zone.UnmarshalText([]byte("northern_ireland")); err != nil || zone != address.ZoneNorthernIreland {
+		t.Errorf("got %v, %v, want %v", zone, err, address.ZoneNorthernIreland)
+	}
+	if err := zone.UnmarshalText([]byte("mars")); err == nil {
+		t.Error("expected an error for an invalid zone.")
+	}
+}